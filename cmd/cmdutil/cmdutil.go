@@ -0,0 +1,148 @@
+// Package cmdutil holds helpers shared by the CLI commands.
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueFilePatterns describes which files in an issues folder are read, for user-facing messages.
+// With recursive set, files in subdirectories are read as well.
+func IssueFilePatterns(recursive bool) string {
+	if recursive {
+		return "**/*.md"
+	}
+	return "*.md"
+}
+
+// ErrorJSON makes Fatalf print a JSON object instead of plain text, for automation.
+var ErrorJSON bool
+
+var errorContext struct {
+	command string
+	owner   string
+	repo    string
+}
+
+// SetErrorCommand records the command reported by Fatalf and Failf.
+func SetErrorCommand(command string) {
+	errorContext.command = command
+}
+
+// SetErrorTarget records the owner and repository reported by Fatalf and Failf.
+func SetErrorTarget(owner, repo string) {
+	errorContext.owner = owner
+	errorContext.repo = repo
+}
+
+// Fatalf reports a fatal error on stderr and exits with status 1. With ErrorJSON set, the error is
+// printed as a JSON object with error, command, owner and repo keys.
+func Fatalf(format string, args ...interface{}) {
+	msg := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	if ErrorJSON {
+		printErrorJSON(msg)
+	} else {
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	os.Exit(1)
+}
+
+// Failf exits with status 1 after a command has already reported its failures on stdout (e.g. a
+// summary of failed checks). Only with ErrorJSON set is the message printed, as the JSON error
+// object Fatalf prints, so automation sees every failing run the same way.
+func Failf(format string, args ...interface{}) {
+	if ErrorJSON {
+		printErrorJSON(strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+	}
+	os.Exit(1)
+}
+
+func printErrorJSON(msg string) {
+	data, err := json.Marshal(struct {
+		Error   string `json:"error"`
+		Command string `json:"command"`
+		Owner   string `json:"owner,omitempty"`
+		Repo    string `json:"repo,omitempty"`
+	}{msg, errorContext.command, errorContext.owner, errorContext.repo})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, msg)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
+// ExitIfNoIssueFiles reports an empty issues folder on stderr and exits when count is zero.
+// The exit status is 0 unless failOnEmpty is set.
+func ExitIfNoIssueFiles(count int, folder string, recursive, failOnEmpty bool) {
+	if count > 0 {
+		return
+	}
+	exitEmpty(noIssueFilesMessage(folder, recursive), failOnEmpty)
+}
+
+// ExitIfNoIssues is ExitIfNoIssueFiles for a single batch or titles file.
+func ExitIfNoIssues(count int, file string, failOnEmpty bool) {
+	if count > 0 {
+		return
+	}
+	exitEmpty(fmt.Sprintf("No issues found in %s", file), failOnEmpty)
+}
+
+func noIssueFilesMessage(folder string, recursive bool) string {
+	return fmt.Sprintf("No issue files found in %s (patterns: %s)", folder, IssueFilePatterns(recursive))
+}
+
+func exitEmpty(msg string, failOnEmpty bool) {
+	if failOnEmpty {
+		Fatalf("%s", msg)
+	}
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(0)
+}
+
+// ParseDuration parses a Go duration (e.g. 36h, 90m) or a whole number of days with a "d"
+// suffix (e.g. 7d).
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	return d, nil
+}
+
+// MarkdownTable renders a GitHub-flavored markdown table. Pipes and line breaks in cells are
+// escaped so every row stays on one line.
+func MarkdownTable(headers []string, rows [][]string) string {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + escape.Replace(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(headers)
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeRow(separators)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
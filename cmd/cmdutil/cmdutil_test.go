@@ -0,0 +1,18 @@
+package cmdutil
+
+import "testing"
+
+func TestNoIssueFilesMessage(t *testing.T) {
+	tests := []struct {
+		recursive bool
+		want      string
+	}{
+		{false, "No issue files found in issues (patterns: *.md)"},
+		{true, "No issue files found in issues (patterns: **/*.md)"},
+	}
+	for _, tt := range tests {
+		if got := noIssueFilesMessage("issues", tt.recursive); got != tt.want {
+			t.Errorf("noIssueFilesMessage(recursive=%v) = %q, want %q", tt.recursive, got, tt.want)
+		}
+	}
+}
@@ -1,17 +1,25 @@
 package create
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/config"
 	"github-issue-manager/pkg/git"
 	ghclient "github-issue-manager/pkg/github"
 	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+	mdparser "github-issue-manager/pkg/mdparser"
 )
 
 // GitHubHostsConfig represents the structure of the hosts.yml file
@@ -41,16 +49,78 @@ var projectID string
 var parentIssueID string
 var owner string
 var repo string
+var idPosition string
+var titlesFile string
+var titlesOut string
+var manageLabel string
+var createMissingLabels bool
+var resolveConcurrency int
+var parallelFiles int
+var recursive bool
+var titleFromH1 bool
+var rewriteLinks string
+var failOnWarning bool
+var strictKeys bool
+var assignParentsOnly bool
+var unlinkRemovedParents bool
+var labelsFile string
+var failOnEmpty bool
+var noWriteID bool
+var sleepBetween time.Duration
+var includeFrontMatter bool
+var typeAliases []string
+var idMapOut string
+var defaultType string
+var statusAliases []string
+var parentCreateIfMissing bool
+var placeholderLabel string
+var placeholderType string
+var batchFile string
+var commentOnUpdate bool
+var onlyNew bool
+var onlyExisting bool
+var titlePrefix string
+var schemaFile string
+var resume bool
+var watch bool
+var force bool
+var typeFromLabels []string
+var bodyTemplates string
+var bodyTemplatesOverwrite bool
+var sortLabels bool
+var apply bool
+var stateFile string
+var labelPrefix string
+var createRepo bool
+var repoTemplate string
+var repoPublic bool
+var assumeYes bool
+var uniqueTitles bool
+var bodyTrim string
+var pruneLabels bool
+var clearRemovedTypes bool
+var forceStatus bool
+var quietSuccess bool
+var cacheTTL time.Duration
+var refreshCache bool
 
 var Cmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create GitHub issues from markdown files",
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		if apply && !watch {
+			cmdutil.Fatalf("--apply only applies to --watch; run create without --dry-run to create issues")
+		}
+		if watch {
+			runWatch(cmd.Context())
+			return
+		}
+
+		ctx := cmd.Context()
 		client := authenticate(ctx)
 
-		// Infer owner and repo from .git/config if not provided via flags
-		inferredOwner, inferredRepo := git.InferOwnerRepoFromGit()
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
 		if owner == "" {
 			owner = inferredOwner
 		}
@@ -63,16 +133,100 @@ var Cmd = &cobra.Command{
 			// Try to read the repository name from the current directory
 			cwd, err := os.Getwd()
 			if err != nil {
-				log.Fatalf("Failed to get current directory: %v", err)
+				cmdutil.Fatalf("Failed to get current directory: %v", err)
 			}
 			repoName = filepath.Base(cwd)
 		}
 
+		cmdutil.SetErrorTarget(owner, repoName)
 		fmt.Printf("Using owner: %s, repo: %s\n", owner, repoName)
 
-		issues, err := issuemanager.ReadIssueFiles(folder)
+		var issues []issuemanager.Issue
+		var err error
+		if batchFile != "" {
+			issues, err = issuemanager.ReadBatchFile(batchFile)
+			if err != nil {
+				cmdutil.Fatalf("Error reading batch file: %v", err)
+			}
+			cmdutil.ExitIfNoIssues(len(issues), batchFile, failOnEmpty)
+		} else if titlesFile != "" {
+			issues, err = issuemanager.ReadTitlesFile(titlesFile)
+			if err != nil {
+				cmdutil.Fatalf("Error reading titles file: %v", err)
+			}
+			cmdutil.ExitIfNoIssues(len(issues), titlesFile, failOnEmpty)
+		} else {
+			trim, err := issuemanager.ParseBodyTrim(bodyTrim)
+			if err != nil {
+				cmdutil.Fatalf("Invalid --body-trim: %v", err)
+			}
+			issues, err = issuemanager.ReadIssueFilesWithOptions(folder, issuemanager.ReadOptions{Workers: parallelFiles, Recursive: recursive, BodyTrim: trim})
+			if err != nil {
+				cmdutil.Fatalf("Error reading issue files: %v", err)
+			}
+			cmdutil.ExitIfNoIssueFiles(len(issues), folder, recursive, failOnEmpty)
+		}
+
+		if titleFromH1 {
+			if err := issuemanager.TitleFromH1(issues); err != nil {
+				cmdutil.Fatalf("Error reading title: %v", err)
+			}
+		}
+
+		// Titles identify parents and existing issues, so duplicates make those lookups ambiguous
+		if duplicates := issuemanager.DuplicateTitles(issues); len(duplicates) > 0 {
+			for _, group := range duplicates {
+				var files []string
+				for _, issue := range group {
+					files = append(files, describeIssue(issue))
+				}
+				if uniqueTitles {
+					fmt.Fprintf(os.Stderr, "duplicate title %q: %s\n", strings.TrimSpace(group[0].Title), strings.Join(files, ", "))
+				} else {
+					logger.Warn("Duplicate title; parent links and updates may pick the wrong issue", "title", strings.TrimSpace(group[0].Title), "files", strings.Join(files, ", "))
+				}
+			}
+			if uniqueTitles {
+				cmdutil.Fatalf("%d duplicate title(s); give every issue a unique title", len(duplicates))
+			}
+		}
+
+		// Hash issues as read, before any flag rewrites them, so an interrupted run can be resumed
+		state, err := issuemanager.LoadState(stateFile)
 		if err != nil {
-			log.Fatalf("Error reading issue files: %v", err)
+			cmdutil.Fatalf("Error reading state file: %v", err)
+		}
+		hashes := make(map[string]string, len(issues))
+		for _, issue := range issues {
+			hashes[issuemanager.StateKey(issue)] = issuemanager.ContentHash(issue)
+		}
+		if resume {
+			var remaining []issuemanager.Issue
+			for _, issue := range issues {
+				if !state.Done(issue) {
+					remaining = append(remaining, issue)
+				}
+			}
+			fmt.Printf("Resuming: skipping %d issues already processed by the interrupted run\n", len(issues)-len(remaining))
+			issues = remaining
+		} else if len(state.Processed) > 0 {
+			logger.Warn("A previous run was interrupted; starting over (use --resume to skip the issues it already processed)", "state_file", stateFile)
+			state.Processed = make(map[string]string)
+		}
+
+		if onlyNew && onlyExisting {
+			cmdutil.Fatalf("--only-new and --only-existing are mutually exclusive")
+		}
+		if onlyNew || onlyExisting {
+			// Partial re-import: keep just the files without (or with) an id
+			var selected []issuemanager.Issue
+			for _, issue := range issues {
+				if (strings.TrimSpace(issue.Id) == "") == onlyNew {
+					selected = append(selected, issue)
+				}
+			}
+			fmt.Printf("Selected %d of %d issues\n", len(selected), len(issues))
+			issues = selected
 		}
 
 		if projectID != "" {
@@ -84,35 +238,584 @@ var Cmd = &cobra.Command{
 
 		}
 
-		fmt.Printf("First issue's project ID: %s\n", issues[0].Project)
+		if strictKeys {
+			for _, issue := range issues {
+				for _, key := range issuemanager.UnknownFrontMatterKeys(issue.FrontMatter) {
+					logger.Warn("Unknown front matter key (prefix custom keys with x- or _)", "file", filepath.Join(issue.Path, issue.FileName), "key", key)
+				}
+			}
+		}
 
-		client.CreateIssues(ctx, owner, repoName, issues)
+		// Enforce the config file's per-type required fields before touching GitHub
+		violations := 0
+		for _, issue := range issues {
+			for _, field := range issuemanager.MissingRequiredFields(issue, config.Current().RequiredFields) {
+				fmt.Fprintf(os.Stderr, "%s: missing field %q required for type %q\n", describeIssue(issue), field, issue.Type)
+				violations++
+			}
+		}
+		if violations > 0 {
+			cmdutil.Fatalf("%d required field(s) missing; fix the files or the required_fields policy", violations)
+		}
+
+		if schemaFile != "" {
+			schema, err := issuemanager.LoadSchema(schemaFile)
+			if err != nil {
+				cmdutil.Fatalf("Error loading schema: %v", err)
+			}
+			count := 0
+			for _, issue := range issues {
+				if issue.FileName == "" {
+					continue
+				}
+				file := filepath.Join(issue.Path, issue.FileName)
+				defaults, err := issuemanager.ReadDefaultsChain(folder, issue.Path)
+				if err != nil {
+					cmdutil.Fatalf("Error checking schema: %v", err)
+				}
+				violations, err := schema.Check(file, defaults)
+				if err != nil {
+					cmdutil.Fatalf("Error checking schema: %v", err)
+				}
+				for _, violation := range violations {
+					fmt.Fprintf(os.Stderr, "%s:%d: %s\n", file, violation.Line, violation.Message)
+				}
+				count += len(violations)
+			}
+			if count > 0 {
+				cmdutil.Fatalf("%d schema violation(s); fix the files or %s", count, schemaFile)
+			}
+		}
+
+		if includeFrontMatter {
+			// Mirror the file verbatim: the raw front matter block goes above the content
+			for i := range issues {
+				if issues[i].FileName == "" {
+					continue
+				}
+				raw, err := mdparser.RawFrontMatter(filepath.Join(issues[i].Path, issues[i].FileName))
+				if err != nil {
+					cmdutil.Fatalf("Error reading front matter: %v", err)
+				}
+				if raw != "" {
+					issues[i].Body = raw + "\n" + issues[i].Body
+				}
+			}
+		}
+
+		if rewriteLinks != "" {
+			// Relative links and images point nowhere once the body lives on GitHub
+			for i := range issues {
+				if rewriteLinks == "warn" {
+					for _, link := range issuemanager.RelativeLinks(issues[i].Body) {
+						logger.Warn("Relative link won't resolve on GitHub (use --rewrite-links <base URL>)", "issue", describeIssue(issues[i]), "link", link)
+					}
+					continue
+				}
+				body, err := issuemanager.RewriteRelativeLinks(issues[i].Body, rewriteLinks)
+				if err != nil {
+					cmdutil.Fatalf("Error rewriting links: %v", err)
+				}
+				issues[i].Body = body
+			}
+		}
+
+		// Label-derived types from the config file, overridden by --issue-type-from-label; an explicit
+		// type: wins and --default-type applies only if no label matches
+		labelTypes := make(map[string]string)
+		for from, to := range config.Current().TypeFromLabels {
+			labelTypes[from] = to
+		}
+		flagLabelTypes, err := config.ParseAliases(typeFromLabels)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --issue-type-from-label: %v", err)
+		}
+		for from, to := range flagLabelTypes {
+			labelTypes[from] = to
+		}
+		for i := range issues {
+			if strings.TrimSpace(issues[i].Type) == "" {
+				issues[i].Type = issuemanager.TypeFromLabels(issues[i], labelTypes)
+			}
+		}
+
+		if bodyTemplates != "" {
+			if err := issuemanager.ApplyBodyTemplates(issues, bodyTemplates, bodyTemplatesOverwrite); err != nil {
+				cmdutil.Fatalf("Error rendering body templates: %v", err)
+			}
+		}
+
+		if titlePrefix != "" {
+			// Parents are prefixed too so they still match the (prefixed) titles on GitHub
+			for i := range issues {
+				issues[i].Title = issuemanager.PrefixTitle(issues[i].Title, titlePrefix)
+				issues[i].Parent = issuemanager.PrefixTitle(strings.TrimSpace(issues[i].Parent), titlePrefix)
+			}
+		}
+
+		if labelPrefix != "" {
+			// Namespace front matter labels; definitions are renamed too so created labels keep their color
+			for i := range issues {
+				for j := range issues[i].Labels {
+					issues[i].Labels[j] = issuemanager.PrefixLabel(issues[i].Labels[j], labelPrefix)
+				}
+				for j := range issues[i].LabelDefinitions {
+					issues[i].LabelDefinitions[j].Name = issuemanager.PrefixLabel(issues[i].LabelDefinitions[j].Name, labelPrefix)
+				}
+			}
+		}
+
+		if sortLabels {
+			for i := range issues {
+				sort.SliceStable(issues[i].Labels, func(a, b int) bool {
+					return strings.ToLower(issues[i].Labels[a]) < strings.ToLower(issues[i].Labels[b])
+				})
+			}
+		}
+
+		if manageLabel != "" {
+			// Mark every issue touched by this run as managed by the tool
+			for i := range issues {
+				issues[i].Labels = appendLabel(issues[i].Labels, manageLabel)
+			}
+		}
+
+		client.CreateMissingLabels = createMissingLabels
+		client.PruneLabels = pruneLabels
+		client.ForceStatus = forceStatus
+		client.QuietSuccess = quietSuccess
+		if quietSuccess {
+			logger.SuppressInfo()
+		}
+
+		// Type aliases from the config file, overridden by --type-alias
+		aliases := make(map[string]string)
+		for from, to := range config.Current().TypeAliases {
+			aliases[from] = to
+		}
+		flagAliases, err := config.ParseAliases(typeAliases)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --type-alias: %v", err)
+		}
+		for from, to := range flagAliases {
+			aliases[from] = to
+		}
+		client.SetTypeAliases(aliases)
+
+		// Status aliases from the config file, overridden by --map-status
+		statuses := make(map[string]string)
+		for from, to := range config.Current().StatusAliases {
+			statuses[from] = to
+		}
+		flagStatuses, err := config.ParseAliases(statusAliases)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --map-status: %v", err)
+		}
+		for from, to := range flagStatuses {
+			statuses[from] = to
+		}
+		client.SetStatusAliases(statuses)
+
+		// Reuse IDs resolved by recent runs; --refresh-cache fetches everything again
+		cachePath := ""
+		if cacheTTL > 0 {
+			if cachePath, err = ghclient.DefaultDiskCachePath(); err != nil {
+				logger.Warn("No user cache directory, not persisting resolved IDs", "error", err)
+			} else if !refreshCache {
+				if err := client.LoadDiskCache(cachePath, cacheTTL); err != nil {
+					logger.Warn("Ignoring unreadable cache file", "file", cachePath, "error", err)
+				}
+			}
+		}
+
+		if _, err := issuemanager.SortIssuesByDependency(issues); err != nil {
+			cmdutil.Fatalf("Cannot order issues: %v", err)
+		}
+
+		if createRepo && !dryRun {
+			ensureRepository(ctx, client, owner, repoName)
+		}
+
+		if err := client.PreflightCreate(ctx, owner, repoName, force); err != nil {
+			cmdutil.Fatalf("Preflight check failed: %v", err)
+		}
+
+		if dryRun {
+			runDryRun(ctx, client, owner, repoName, issues)
+			saveDiskCache(client, cachePath)
+			return
+		}
+
+		if assignParentsOnly {
+			linked, failed := client.AssignParents(ctx, owner, repoName, issues)
+			fmt.Printf("Assigned parents for %d issues (%d failed).\n", linked, failed)
+			if failed > 0 {
+				cmdutil.Failf("Failed to assign parents for %d issue(s)", failed)
+			}
+			return
+		}
+
+		position, err := issuemanager.ParseIDPosition(idPosition)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --id-position: %v", err)
+		}
+
+		if labelsFile != "" {
+			// Create the whole label set up front so per-issue label resolution never misses
+			labels, err := issuemanager.ReadLabelsFile(labelsFile)
+			if err != nil {
+				cmdutil.Fatalf("Error reading labels file: %v", err)
+			}
+			created, err := client.EnsureLabels(ctx, owner, repoName, labels)
+			if err != nil {
+				cmdutil.Fatalf("Failed to ensure labels exist: %v", err)
+			}
+			fmt.Printf("Ensured %d labels exist (%d created).\n", len(labels), created)
+		}
+
+		if resolveConcurrency > 0 {
+			// Warm the resolution cache before the (sequential) create loop starts
+			client.PrefetchResolutions(ctx, owner, repoName, issues, resolveConcurrency)
+		}
+
+		results := client.CreateIssues(ctx, owner, repoName, issues, ghclient.CreateOptions{
+			IDPosition:           position,
+			UnlinkRemovedParents: unlinkRemovedParents,
+			ClearRemovedTypes:    clearRemovedTypes,
+			NoWriteID:            noWriteID,
+			SleepBetween:         sleepBetween,
+			DefaultType:          defaultType,
+			CreateMissingParents: parentCreateIfMissing,
+			PlaceholderLabel:     placeholderLabel,
+			PlaceholderType:      placeholderType,
+			CommentOnUpdate:      commentOnUpdate,
+			OnProcessed: func(result ghclient.CreateResult) {
+				if err := state.Record(result.Issue, hashes[issuemanager.StateKey(result.Issue)]); err != nil {
+					logger.Warn("Failed to update state file", "file", stateFile, "error", err)
+				}
+			},
+		})
+
+		saveDiskCache(client, cachePath)
+
+		failures := 0
+		for _, result := range results {
+			if result.Err != nil {
+				failures++
+			}
+		}
+		if failures == 0 {
+			if err := state.Remove(); err != nil {
+				logger.Warn("Failed to remove state file", "file", stateFile, "error", err)
+			}
+		} else {
+			fmt.Printf("%d issue(s) failed; progress saved to %s, re-run with --resume to retry just the rest\n", failures, stateFile)
+		}
+
+		if batchFile != "" && !noWriteID {
+			// Batch entries have no file of their own; write the new numbers back into the batch file
+			ids := make(map[int]int64)
+			for _, result := range results {
+				if result.Created && result.Err == nil && !result.Placeholder {
+					ids[result.Issue.BatchIndex] = result.Number
+				}
+			}
+			if err := issuemanager.WriteBatchIDs(batchFile, ids); err != nil {
+				logger.Error("Failed to write ids to batch file", "file", batchFile, "error", err)
+				for i := range results {
+					if _, ok := ids[results[i].Issue.BatchIndex]; ok && results[i].Created && results[i].Err == nil && !results[i].Placeholder {
+						results[i].WriteIDErr = err
+					}
+				}
+			}
+		}
+
+		if titlesOut != "" {
+			if err := writeTitleMap(titlesOut, results); err != nil {
+				cmdutil.Fatalf("Failed to write title map: %v", err)
+			}
+			fmt.Printf("Wrote title to issue number mapping to %s\n", titlesOut)
+		}
+
+		if idMapOut != "" {
+			if err := writeIDMap(idMapOut, results); err != nil {
+				cmdutil.Fatalf("Failed to write id map: %v", err)
+			}
+			fmt.Printf("Wrote issue id mapping to %s\n", idMapOut)
+		}
+
+		if used, limit := client.RetriesUsed(); used > 0 {
+			if limit > 0 {
+				fmt.Printf("Used %d of %d retries (--max-retries-total)\n", used, limit)
+			} else {
+				fmt.Printf("Used %d retries\n", used)
+			}
+		}
+
+		unwritten := reportUnwrittenIDs(results)
+
+		if warnings := logger.Warnings(); len(warnings) > 0 {
+			fmt.Fprintf(os.Stderr, "\n%d warning(s) emitted during this run:\n", len(warnings))
+			for _, warning := range warnings {
+				fmt.Fprintf(os.Stderr, "  - %s\n", warning)
+			}
+			if failOnWarning {
+				cmdutil.Fatalf("Failing because --fail-on-warning is set")
+			}
+		}
+
+		if unwritten > 0 {
+			cmdutil.Fatalf("%d created issue(s) have no id in their file; add them by hand before the next run", unwritten)
+		}
+
+		// Failed, cancelled or timed out issues were reported above; exit non-zero so scripts notice
+		if reason := failureReason(ctx.Err(), failures); reason != "" {
+			cmdutil.Failf("%s", reason)
+		}
 	},
 }
 
+// failureReason returns why a create run should exit with status 1, or "" when it went through.
+func failureReason(ctxErr error, failures int) string {
+	if ctxErr != nil {
+		return fmt.Sprintf("Stopped early (%v); %d issue(s) failed", ctxErr, failures)
+	}
+	if failures > 0 {
+		return fmt.Sprintf("%d issue(s) failed", failures)
+	}
+	return ""
+}
+
+// reportUnwrittenIDs prints the issues that were created on GitHub but whose id couldn't be written
+// back, since re-running would create them again. It returns how many there were.
+func reportUnwrittenIDs(results []ghclient.CreateResult) int {
+	count := 0
+	for _, result := range results {
+		if result.WriteIDErr == nil {
+			continue
+		}
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "\n!!! These issues were created but their ids could NOT be written back (risk of duplicates on re-run):")
+		}
+		count++
+		fmt.Fprintf(os.Stderr, "  - %s: #%d %s (%v)\n", describeIssue(result.Issue), result.Number, result.URL, result.WriteIDErr)
+	}
+	return count
+}
+
 func init() {
 	// Dry run flag
-	Cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Dry run mode")
+	Cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Resolve every referenced type, label, parent and project (read-only) and preview changes without creating anything")
+	Cmd.Flags().BoolVar(&watch, "watch", false, "Re-run as a dry run whenever an issue file changes, printing what changed in the plan")
+	Cmd.Flags().BoolVar(&apply, "apply", false, "With --watch, actually create and update issues on each change instead of a dry run")
 	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
 	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
 	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
 	Cmd.Flags().StringVarP(&projectID, "project", "p", "", "GitHub project ID to assign issues to")
 	Cmd.Flags().StringVarP(&parentIssueID, "parent", "m", "", "Parent issue ID")
+	Cmd.Flags().BoolVar(&createRepo, "create-repo", false, "Create the repository (private unless --repo-public) if it doesn't exist, after confirmation")
+	Cmd.Flags().StringVar(&repoTemplate, "repo-template", "", "Template repository (owner/repo) to generate the repository from with --create-repo")
+	Cmd.Flags().BoolVar(&repoPublic, "repo-public", false, "Make a repository created by --create-repo public")
+	Cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Don't ask for confirmation before creating the repository")
+	Cmd.Flags().StringVar(&batchFile, "batch", "", "Create issues from a single YAML or JSON file holding a list of issues")
+	Cmd.Flags().StringVar(&titlesFile, "titles-file", "", "Create title-only issues from a file with one title per line")
+	Cmd.Flags().StringVar(&titlesOut, "titles-out", "", "Write a tab-separated title to issue number mapping to this file")
+	Cmd.Flags().StringVar(&idMapOut, "id-map-out", "", "Write a JSON mapping of issue title to number, URL and file to this file")
+	Cmd.Flags().StringVar(&titlePrefix, "title-prefix", "", "Prefix every issue (and parent) title, e.g. \"[staging] \" when targeting a staging repository")
+	Cmd.Flags().StringVar(&labelPrefix, "label-prefix", "", "Prefix every front matter label, e.g. \"team:\" (labels that already carry it are left alone; not applied to --manage-label)")
+	Cmd.Flags().BoolVar(&sortLabels, "sort-labels", false, "Apply labels in alphabetical order instead of front matter order")
+	Cmd.Flags().StringVar(&manageLabel, "manage-label", "", "Label applied to every issue created or updated by this run (e.g. gim-managed)")
+	Cmd.Flags().BoolVar(&pruneLabels, "prune-labels", false, "Remove labels from updated issues that are no longer in their front matter, so labels match the file exactly")
+	Cmd.Flags().BoolVar(&createMissingLabels, "create-missing-labels", false, "Create labels that don't exist in the repository")
+	Cmd.Flags().BoolVar(&createMissingLabels, "create-labels", false, "Same as --create-missing-labels")
+	Cmd.Flags().BoolVar(&titleFromH1, "title-from-h1", false, "Use the first # heading of the body (removed from the body) as the title of files without title:")
+	Cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Also read issue files in subdirectories of the folder (e.g. one per type)")
+	Cmd.Flags().IntVar(&parallelFiles, "parallel-files", 1, "Number of issue files parsed concurrently")
+	Cmd.Flags().DurationVar(&cacheTTL, "cache-ttl", 0, "Persist resolved repository, label, type and project IDs in the user cache directory and reuse them for this long (e.g. 1h; 0 disables)")
+	Cmd.Flags().BoolVar(&refreshCache, "refresh-cache", false, "Ignore IDs cached by previous runs (with --cache-ttl) and resolve everything again")
+	Cmd.Flags().IntVar(&resolveConcurrency, "resolve-concurrency", 4, "Number of parallel read-only lookups used to prefetch repository, label, type and project IDs (0 disables prefetching)")
+	Cmd.Flags().BoolVar(&force, "force", false, "Proceed (with a warning) even if the repository is archived")
+	Cmd.Flags().BoolVar(&quietSuccess, "quiet-success", false, "Only print failures, warnings and the final summary, not each successful create or update")
+	Cmd.Flags().BoolVar(&failOnWarning, "fail-on-warning", false, "Exit non-zero if any warning was emitted during the run")
+	Cmd.Flags().StringVar(&schemaFile, "schema", "", "YAML schema of allowed front matter keys, kinds and values; any violation aborts the run before touching GitHub")
+	Cmd.Flags().BoolVar(&uniqueTitles, "unique-titles", false, "Abort before touching GitHub if two issues share a title (ignoring case) instead of only warning")
+	Cmd.Flags().BoolVar(&strictKeys, "strict-keys", false, "Warn about unknown front matter keys")
+	Cmd.Flags().BoolVar(&onlyNew, "only-new", false, "Only create files that don't have an id yet; leave existing issues untouched")
+	Cmd.Flags().BoolVar(&onlyExisting, "only-existing", false, "Only update files that already have an id; don't create new issues")
+	Cmd.Flags().BoolVar(&assignParentsOnly, "assign-parents-only", false, "Only (re)establish parent relationships for existing issues; don't create or update anything else")
+	Cmd.Flags().BoolVar(&unlinkRemovedParents, "unlink-removed-parents", false, "Remove the GitHub parent of existing issues whose file no longer specifies a parent")
+	Cmd.Flags().BoolVar(&clearRemovedTypes, "clear-removed-types", false, "Clear the GitHub issue type of existing issues whose file no longer specifies a type")
+	Cmd.Flags().StringVar(&labelsFile, "labels-file", "", "YAML file of labels to create (if missing) before any issue is created")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+	Cmd.Flags().BoolVar(&resume, "resume", false, "Skip issues an interrupted or failed previous run already processed (unchanged since, per the state file)")
+	Cmd.Flags().StringVar(&stateFile, "state-file", issuemanager.DefaultStateFile, "State manifest recording the progress of a run; removed once a run completes without failures")
+	Cmd.Flags().BoolVar(&noWriteID, "no-write-id", false, "Don't write new issue numbers back into the markdown files")
+	Cmd.Flags().DurationVar(&sleepBetween, "sleep-between", 0, "Pause between issues (e.g. 500ms, 2s) to stay under secondary rate limits")
+	Cmd.Flags().StringVar(&bodyTrim, "body-trim", "edges", "Whitespace trimmed from bodies: none, edges (leading/trailing blank lines) or all (also every line's indentation)")
+	Cmd.Flags().BoolVar(&includeFrontMatter, "include-front-matter-in-body", false, "Prepend the raw front matter block to the issue body")
+	Cmd.Flags().StringVar(&bodyTemplates, "body-templates", "", "Folder of per-type body templates (e.g. bug.md.tmpl) rendered from each issue's front matter for files without a body")
+	Cmd.Flags().BoolVar(&bodyTemplatesOverwrite, "body-templates-overwrite", false, "Also render body templates for files that have a body (available to the template as .Body)")
+	Cmd.Flags().StringVar(&rewriteLinks, "rewrite-links", "", "Rewrite relative links and images in bodies against this base URL (e.g. the raw URL of the issue folder), or \"warn\" to only report them")
+	Cmd.Flags().StringVar(&defaultType, "default-type", "", "Issue type for files without a type: (skipped with a warning if the repository doesn't have it)")
+	Cmd.Flags().StringArrayVar(&typeAliases, "type-alias", nil, "Map a local issue type to the repository's type name, e.g. Feature=Enhancement (repeatable; overrides type_aliases in the config file)")
+	Cmd.Flags().StringArrayVar(&typeFromLabels, "issue-type-from-label", nil, "Derive the type of files without type: from a label, e.g. bug=Bug (repeatable; overrides type_from_labels in the config file)")
+	Cmd.Flags().BoolVar(&forceStatus, "force-status", false, "Overwrite the project Status of issues that already have one (by default a status from the file is only applied when the issue is first added to the project)")
+	Cmd.Flags().StringArrayVar(&statusAliases, "map-status", nil, "Map a local status value to the project's Status option, e.g. todo=Backlog (repeatable; overrides status_aliases in the config file)")
+	Cmd.Flags().BoolVar(&parentCreateIfMissing, "parent-create-if-missing", false, "Create a title-only placeholder issue for parents that exist neither locally nor on GitHub")
+	Cmd.Flags().StringVar(&placeholderLabel, "placeholder-label", "", "Label applied to placeholder parents created by --parent-create-if-missing")
+	Cmd.Flags().StringVar(&placeholderType, "placeholder-type", "", "Issue type of placeholder parents created by --parent-create-if-missing")
+	Cmd.Flags().BoolVar(&commentOnUpdate, "comment-on-update", false, "Comment on each updated issue with a summary of the fields that changed")
+	Cmd.Flags().StringVar(&idPosition, "id-position", "last", "Where to insert a new id line in front matter (first, last)")
+}
+
+// runDryRun reports unresolvable references and the changes a real run would make, exiting
+// non-zero if any reference doesn't resolve.
+func runDryRun(ctx context.Context, client *ghclient.Client, owner, repo string, issues []issuemanager.Issue) {
+	var plannedLabels []string
+	if labelsFile != "" {
+		labels, err := issuemanager.ReadLabelsFile(labelsFile)
+		if err != nil {
+			cmdutil.Fatalf("Error reading labels file: %v", err)
+		}
+		for _, label := range labels {
+			plannedLabels = append(plannedLabels, label.Name)
+		}
+	}
+
+	report := client.DryRun(ctx, owner, repo, issues, plannedLabels, ghclient.CreateOptions{
+		DefaultType:          defaultType,
+		CreateMissingParents: parentCreateIfMissing,
+	})
+
+	fmt.Println("Dry run: planned changes")
+	for _, action := range report.Actions {
+		fmt.Printf("  [plan]  %s: %s\n", action.Issue.Title, action.Description)
+	}
+
+	if len(report.Problems) > 0 {
+		fmt.Println("Dry run: resolution errors")
+		for _, problem := range report.Problems {
+			fmt.Printf("  [error] %s: %s %q: %v\n", describeIssue(problem.Issue), problem.Kind, problem.Name, problem.Err)
+		}
+	}
+
+	fmt.Printf("Dry run complete: %d issues, %d planned changes, %d resolution errors. Nothing was created.\n", len(issues), len(report.Actions), len(report.Problems))
+	if len(report.Problems) > 0 {
+		cmdutil.Failf("Dry run found %d resolution error(s)", len(report.Problems))
+	}
+}
+
+// ensureRepository creates owner/name when it doesn't exist yet, asking for confirmation unless --yes is set.
+func ensureRepository(ctx context.Context, client *ghclient.Client, owner, name string) {
+	_, err := client.ResolveRepositoryID(ctx, owner, name)
+	if err == nil {
+		return
+	}
+	if !ghclient.IsNotFound(err) {
+		cmdutil.Fatalf("Failed to look up repository: %v", err)
+	}
+
+	visibility := "private"
+	if repoPublic {
+		visibility = "public"
+	}
+	if !assumeYes {
+		fmt.Printf("Repository %s/%s doesn't exist. Create it (%s)? [y/N] ", owner, name, visibility)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			cmdutil.Fatalf("Repository %s/%s doesn't exist", owner, name)
+		}
+	}
+
+	if _, err := client.CreateRepository(ctx, owner, name, repoTemplate, repoPublic); err != nil {
+		cmdutil.Fatalf("Failed to create repository: %v", err)
+	}
+	fmt.Printf("Created %s repository %s/%s\n", visibility, owner, name)
+}
+
+// saveDiskCache persists the IDs resolved by this run for the next one; path is empty when the
+// disk cache is disabled.
+func saveDiskCache(client *ghclient.Client, path string) {
+	if path == "" {
+		return
+	}
+	if err := client.SaveDiskCache(path); err != nil {
+		logger.Warn("Failed to write cache file", "file", path, "error", err)
+	}
+}
+
+// describeIssue names an issue by its file when it has one, otherwise by its title.
+func describeIssue(issue issuemanager.Issue) string {
+	if issue.FileName != "" {
+		return filepath.Join(issue.Path, issue.FileName)
+	}
+	return issue.Title
 }
 
 func authenticate(ctx context.Context) *ghclient.Client {
-	token := os.Getenv("GITHUB_TOKEN")
+	token := ghclient.TokenFromEnv()
 	if token == "" {
 		// Try to read token from hosts file
 		hostsToken, err := ghclient.ReadTokenFromHostsFile()
 		if err != nil {
-			log.Fatalf("Failed to read token from hosts file: %v", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
 		}
 		if hostsToken != "" {
 			token = hostsToken
 		} else {
-			log.Fatal("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
 		}
 	}
 	return ghclient.NewClient(ctx, token)
 }
+
+// idMapEntry is the value written per issue title by --id-map-out.
+type idMapEntry struct {
+	Number int64  `json:"number"`
+	URL    string `json:"url"`
+	File   string `json:"file,omitempty"`
+}
+
+// writeIDMap writes a JSON object mapping each successfully created or updated issue's title to its
+// number, URL and source file.
+func writeIDMap(path string, results []ghclient.CreateResult) error {
+	entries := make(map[string]idMapEntry)
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		entry := idMapEntry{Number: result.Number, URL: result.URL}
+		if result.Issue.FileName != "" {
+			entry.File = filepath.Join(result.Issue.Path, result.Issue.FileName)
+		}
+		entries[result.Issue.Title] = entry
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// writeTitleMap writes one "title<TAB>number" line per successfully processed issue.
+func writeTitleMap(path string, results []ghclient.CreateResult) error {
+	var b strings.Builder
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s\t%d\n", result.Issue.Title, result.Number)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// appendLabel adds label to labels unless it is already present (case-insensitive).
+func appendLabel(labels []string, label string) []string {
+	for _, existing := range labels {
+		if strings.EqualFold(strings.TrimSpace(existing), strings.TrimSpace(label)) {
+			return labels
+		}
+	}
+	return append(labels, label)
+}
@@ -0,0 +1,28 @@
+package create
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctxErr   error
+		failures int
+		want     string
+	}{
+		{name: "every issue went through", want: ""},
+		{name: "some issues failed", failures: 2, want: "2 issue(s) failed"},
+		{name: "cancelled", ctxErr: context.Canceled, failures: 3, want: "Stopped early (context canceled); 3 issue(s) failed"},
+		{name: "timed out", ctxErr: context.DeadlineExceeded, failures: 1, want: "Stopped early (context deadline exceeded); 1 issue(s) failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failureReason(tt.ctxErr, tt.failures); got != tt.want {
+				t.Errorf("failureReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
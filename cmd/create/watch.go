@@ -0,0 +1,210 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/logger"
+)
+
+// watchDebounce is how long the issue folder must stay quiet after a change before the pipeline
+// re-runs (editors often write files in bursts).
+const watchDebounce = 750 * time.Millisecond
+
+// runWatch re-runs create whenever a file in the issue folder changes: as a dry run unless --apply
+// is set. Each run is a separate process so a failing run doesn't end the watch. After the first
+// run only the lines of the output that changed are printed. It returns when ctx is cancelled.
+func runWatch(ctx context.Context) {
+	self, err := os.Executable()
+	if err != nil {
+		cmdutil.Fatalf("Failed to locate executable: %v", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		cmdutil.Fatalf("Failed to start file watcher: %v", err)
+	}
+	defer watcher.Close()
+	if err := watchFolder(watcher, folder); err != nil {
+		cmdutil.Fatalf("Failed to watch %s: %v", folder, err)
+	}
+
+	var args []string
+	for _, arg := range os.Args[1:] {
+		if arg == "--watch" || strings.HasPrefix(arg, "--watch=") || arg == "--apply" || strings.HasPrefix(arg, "--apply=") {
+			continue
+		}
+		args = append(args, arg)
+	}
+	if !apply {
+		args = append(args, "--dry-run")
+	}
+
+	mode := "dry run"
+	if apply {
+		mode = "applying changes"
+	}
+	fmt.Printf("Watching %s for changes (%s); press Ctrl-C to stop\n", folder, mode)
+
+	var previous []string
+	for {
+		output, err := exec.CommandContext(ctx, self, args...).CombinedOutput()
+		if ctx.Err() != nil {
+			return
+		}
+		lines := strings.Split(strings.TrimRight(string(bytes.TrimSpace(output)), "\n"), "\n")
+		fmt.Printf("\n--- %s ---\n", time.Now().Format("15:04:05"))
+		if previous == nil {
+			fmt.Println(strings.Join(lines, "\n"))
+		} else {
+			printLineDiff(os.Stdout, previous, lines)
+		}
+		if err != nil {
+			fmt.Printf("(run failed: %v)\n", err)
+		}
+		previous = lines
+
+		// Snapshot after the run so ids written back by --apply don't trigger another run
+		if !waitForChange(ctx, watcher, folder, folderSnapshot(folder)) {
+			return
+		}
+	}
+}
+
+// watchFolder adds dir, and with --recursive every folder below it, to watcher.
+func watchFolder(watcher *fsnotify.Watcher, dir string) error {
+	if !recursive {
+		return watcher.Add(dir)
+	}
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// waitForChange blocks until an issue or defaults file under dir changes and the folder then stays
+// quiet for watchDebounce. Events that leave the folder as it was in snapshot (e.g. the id writes of
+// the run that just finished) are ignored. It returns false when ctx is cancelled first.
+func waitForChange(ctx context.Context, watcher *fsnotify.Watcher, dir string, snapshot map[string]string) bool {
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return false
+			}
+			if recursive && event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watchFolder(watcher, event.Name); err != nil {
+						logger.Warn("Failed to watch new folder", "folder", event.Name, "error", err)
+					}
+				}
+			}
+			if watchedFile(event.Name) || event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) {
+				debounce = time.After(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return false
+			}
+			logger.Warn("File watcher error", "error", err)
+		case <-debounce:
+			debounce = nil
+			current := folderSnapshot(dir)
+			if !sameSnapshot(snapshot, current) {
+				return true
+			}
+		}
+	}
+}
+
+// watchedFile reports whether changes to path can change the plan: issue files and defaults files.
+func watchedFile(path string) bool {
+	return strings.HasSuffix(path, ".md") || strings.HasSuffix(path, ".yaml")
+}
+
+// folderSnapshot records the size and modification time of the issue and defaults files under dir.
+func folderSnapshot(dir string) map[string]string {
+	snapshot := make(map[string]string)
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !watchedFile(entry.Name()) {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			snapshot[path] = fmt.Sprintf("%d/%d", info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return snapshot
+}
+
+func sameSnapshot(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		if b[path] != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// printLineDiff writes the lines only in previous with "- " and the lines only in current with "+ ".
+func printLineDiff(w io.Writer, previous, current []string) {
+	counts := make(map[string]int)
+	for _, line := range previous {
+		counts[line]++
+	}
+	var added []string
+	for _, line := range current {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		added = append(added, line)
+	}
+
+	changed := false
+	for _, line := range previous {
+		if counts[line] > 0 {
+			counts[line]--
+			fmt.Fprintln(w, "- "+line)
+			changed = true
+		}
+	}
+	for _, line := range added {
+		fmt.Fprintln(w, "+ "+line)
+		changed = true
+	}
+	if !changed {
+		fmt.Fprintln(w, "No changes.")
+	}
+}
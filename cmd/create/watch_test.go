@@ -0,0 +1,78 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestPrintLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous []string
+		current  []string
+		want     string
+	}{
+		{
+			name:     "no changes",
+			previous: []string{"a", "b"},
+			current:  []string{"a", "b"},
+			want:     "No changes.\n",
+		},
+		{
+			name:     "added and removed lines",
+			previous: []string{"plan A", "plan B", "summary 2"},
+			current:  []string{"plan A", "plan C", "summary 2"},
+			want:     "- plan B\n+ plan C\n",
+		},
+		{
+			name:     "repeated lines are counted",
+			previous: []string{"x", "x", "y"},
+			current:  []string{"x", "y", "y"},
+			want:     "- x\n+ y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			printLineDiff(&out, tt.previous, tt.current)
+			if got := out.String(); got != tt.want {
+				t.Errorf("printLineDiff output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	dir := t.TempDir()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	if err := watchFolder(watcher, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	snapshot := folderSnapshot(dir)
+	if err := os.WriteFile(filepath.Join(dir, "issue.md"), []byte("---\ntitle: T\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !waitForChange(ctx, watcher, dir, snapshot) {
+		t.Fatal("waitForChange = false after an issue file was written, want true")
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if waitForChange(cancelled, watcher, dir, folderSnapshot(dir)) {
+		t.Error("waitForChange = true with a cancelled context, want false")
+	}
+}
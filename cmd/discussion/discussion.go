@@ -0,0 +1,112 @@
+package discussion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var owner string
+var repo string
+var number int64
+var issueType string
+var labels []string
+var project string
+var noBacklink bool
+
+var Cmd = &cobra.Command{
+	Use:   "from-discussion",
+	Short: "Create an issue from a GitHub discussion",
+	Long:  "Create an issue from a discussion's title and body and comment on the discussion with a link to the new issue.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		cmdutil.SetErrorTarget(owner, repo)
+		if owner == "" || repo == "" {
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+		}
+
+		discussion, err := client.GetDiscussion(ctx, owner, repo, number)
+		if err != nil {
+			cmdutil.Fatalf("Failed to get discussion #%d: %v", number, err)
+		}
+
+		issue := issuemanager.Issue{
+			Title:   discussion.Title,
+			Body:    fmt.Sprintf("%s\n\n---\n_Converted from discussion #%d (%s)_", strings.TrimSpace(discussion.Body), discussion.Number, discussion.URL),
+			Labels:  labels,
+			Type:    issueType,
+			Project: project,
+		}
+
+		if err := client.PreflightCreate(ctx, owner, repo, false); err != nil {
+			cmdutil.Fatalf("Preflight check failed: %v", err)
+		}
+
+		results := client.CreateIssues(ctx, owner, repo, []issuemanager.Issue{issue}, ghclient.CreateOptions{})
+		if len(results) == 0 || results[0].Err != nil {
+			cmdutil.Fatalf("Failed to create issue from discussion #%d", number)
+		}
+		result := results[0]
+		fmt.Printf("Created issue #%d (%s) from discussion #%d\n", result.Number, result.URL, discussion.Number)
+
+		if noBacklink {
+			return
+		}
+		commentURL, err := client.AddDiscussionComment(ctx, discussion.ID, fmt.Sprintf("Tracked in #%d.", result.Number))
+		if err != nil {
+			logger.Warn("Failed to comment on the discussion", "discussion", discussion.Number, "error", err)
+			cmdutil.Fatalf("Issue created, but commenting on discussion #%d failed: %v", discussion.Number, err)
+		}
+		fmt.Printf("Linked the issue from the discussion (%s)\n", commentURL)
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().Int64VarP(&number, "discussion", "n", 0, "Number of the discussion to convert")
+	Cmd.Flags().StringVarP(&issueType, "type", "t", "", "Issue type for the new issue")
+	Cmd.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "Label for the new issue (can be used multiple times)")
+	Cmd.Flags().StringVarP(&project, "project", "p", "", "Project to add the new issue to")
+	Cmd.Flags().BoolVar(&noBacklink, "no-backlink", false, "Don't comment on the discussion with a link to the new issue")
+	Cmd.MarkFlagRequired("discussion")
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
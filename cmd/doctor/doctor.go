@@ -0,0 +1,93 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+)
+
+var owner string
+var repo string
+
+var Cmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the environment is ready to create issues",
+	Long:  "Check authentication, repository resolution and repository settings, reporting anything that would make create fail.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		failed := false
+
+		token := ghclient.TokenFromEnv()
+		if token != "" {
+			pass("GitHub token found in the environment")
+		} else if hostsToken, err := ghclient.ReadTokenFromHostsFile(); err == nil && hostsToken != "" {
+			token = hostsToken
+			pass("GitHub token found in GitHub CLI hosts file")
+		} else {
+			fail("No GitHub token: set GITHUB_TOKEN or run 'gh auth login'")
+			cmdutil.Failf("No GitHub token: set GITHUB_TOKEN or run 'gh auth login'")
+		}
+		client := ghclient.NewClient(ctx, token)
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		if owner == "" || repo == "" {
+			fail("Owner and repository could not be determined: pass --owner/--repo or run inside a clone with an origin remote")
+			cmdutil.Failf("Owner and repository could not be determined")
+		}
+		pass(fmt.Sprintf("Target repository: %s/%s", owner, repo))
+		cmdutil.SetErrorTarget(owner, repo)
+
+		status, err := client.GetRepositoryStatus(ctx, owner, repo)
+		if err != nil {
+			fail(fmt.Sprintf("Repository is not accessible: %v", err))
+			cmdutil.Failf("Repository is not accessible: %v", err)
+		}
+		pass("Repository is accessible")
+
+		if status.HasIssuesEnabled {
+			pass("Issues are enabled")
+		} else {
+			fail("Issues are disabled: enable them under Settings > General > Features")
+			failed = true
+		}
+
+		switch {
+		case status.IsArchived:
+			fail("Repository is archived and doesn't accept new issues")
+			failed = true
+		case status.CanCreateIssues():
+			pass(fmt.Sprintf("Token can create issues (permission: %s)", status.ViewerPermission))
+		default:
+			fail(fmt.Sprintf("You lack issue-creation permission on %s/%s: check the token's repository access", owner, repo))
+			failed = true
+		}
+
+		if failed {
+			cmdutil.Failf("One or more doctor checks failed")
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+}
+
+func pass(msg string) {
+	fmt.Printf("[ok]   %s\n", msg)
+}
+
+func fail(msg string) {
+	fmt.Printf("[fail] %s\n", msg)
+}
@@ -3,12 +3,18 @@ package examples
 import (
 	"embed"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
 )
 
 //go:embed templates/*.tmpl
@@ -16,6 +22,13 @@ var templatesFS embed.FS
 
 var outputDir string
 var issueType string
+var toStdout bool
+var overwrite bool
+var unique bool
+var dirPerType bool
+
+// skippedFiles collects the files that were not written because they already existed
+var skippedFiles []string
 
 // Flag variables for IssueData fields
 var (
@@ -54,6 +67,9 @@ var Cmd = &cobra.Command{
 	Short: "Generate example issue files with all available fields",
 	Long:  "Generate example markdown issue files for different types (Epic, Task, Bug, Feature) with all available fields and parent-child relationships",
 	Run: func(cmd *cobra.Command, args []string) {
+		if toStdout && issueType == "" {
+			cmdutil.Fatalf("--stdout requires --type")
+		}
 		if issueType != "" {
 			generateSingleExample(issueType)
 		} else {
@@ -65,6 +81,10 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.Flags().StringVarP(&outputDir, "output", "o", "examples", "Output directory for example files")
 	Cmd.Flags().StringVarP(&issueType, "type", "t", "", "Generate example for specific type (epic, task, bug, feature)")
+	Cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite existing example files")
+	Cmd.Flags().BoolVar(&unique, "unique", false, "When not overwriting, write colliding files with a numeric suffix instead of skipping them")
+	Cmd.Flags().BoolVar(&dirPerType, "dir-per-type", false, "Write files into per-type subdirectories (epics/, tasks/, bugs/, features/); read them back with create --recursive")
+	Cmd.Flags().BoolVar(&toStdout, "stdout", false, "Write the rendered example to stdout instead of a file (requires --type)")
 
 	// String field flags
 	Cmd.Flags().StringVarP(&flagTitle, "title", "", "", "Issue title")
@@ -95,37 +115,44 @@ func init() {
 	Cmd.Flags().StringVarP(&flagWorkaround, "workaround", "", "", "Workaround description")
 }
 
-// parseFixDescription parses the fix description flag format "0:Description1;1:Description2"
-func parseFixDescription(input string) []struct {
-	Index       int
-	Description string
-} {
-	var result []struct {
-		Index       int
-		Description string
-	}
-
-	if input == "" {
-		return result
-	}
-
-	parts := strings.Split(input, ";")
-	for _, part := range parts {
-		if colonIndex := strings.Index(part, ":"); colonIndex > 0 {
-			indexStr := part[:colonIndex]
-			description := part[colonIndex+1:]
-			if index := 0; true {
-				if _, err := fmt.Sscanf(indexStr, "%d", &index); err == nil {
-					result = append(result, struct {
-						Index       int
-						Description string
-					}{Index: index, Description: description})
-				}
-			}
+// parseFixDescription parses the fix description flag format "0:Description1;1:Description2".
+// Empty segments are ignored; any other malformed segment is reported as an error.
+// The result is sorted by index.
+func parseFixDescription(input string) ([]FixDescriptionItem, error) {
+	var result []FixDescriptionItem
+
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(input, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		indexStr, description, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fix description segment %q: expected 'index:description'", part)
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(indexStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fix description segment %q: index %q is not a number", part, indexStr)
 		}
+		if index < 0 {
+			return nil, fmt.Errorf("invalid fix description segment %q: index must not be negative", part)
+		}
+		if seen[index] {
+			return nil, fmt.Errorf("invalid fix description segment %q: duplicate index %d", part, index)
+		}
+
+		description = strings.TrimSpace(description)
+		if description == "" {
+			return nil, fmt.Errorf("invalid fix description segment %q: description is empty", part)
+		}
+
+		seen[index] = true
+		result = append(result, FixDescriptionItem{Index: index, Description: description})
 	}
 
-	return result
+	return sortedFixDescription(result), nil
 }
 
 // createDefaultIssueData creates an IssueData struct with default example values
@@ -215,10 +242,7 @@ func createDefaultIssueData(issueType string) IssueData {
 				"Race condition in state management",
 				"Insufficient validation",
 			},
-			FixDescription: []struct {
-				Index       int
-				Description string
-			}{
+			FixDescription: []FixDescriptionItem{
 				{0, "Add proper state synchronization"},
 				{1, "Implement validation checks"},
 				{2, "Add error handling"},
@@ -299,10 +323,13 @@ type IssueData struct {
 	BusinessImpact     string
 	Workaround         string
 	RootCause          []string
-	FixDescription     []struct {
-		Index       int
-		Description string
-	}
+	FixDescription     []FixDescriptionItem
+}
+
+// FixDescriptionItem is a single numbered step of a bug's fix description.
+type FixDescriptionItem struct {
+	Index       int
+	Description string
 }
 
 func generateAllExamples() {
@@ -329,10 +356,17 @@ func generateAllExamples() {
 	fmt.Printf("Example files generated successfully in %s/\n", outputDir)
 	fmt.Println("\nGenerated files:")
 	listGeneratedFiles()
+
+	if len(skippedFiles) > 0 {
+		fmt.Printf("\nSkipped %d existing file(s):\n", len(skippedFiles))
+		for _, file := range skippedFiles {
+			fmt.Printf("  - %s\n", file)
+		}
+	}
 }
 
 // applyFlagOverrides applies command-line flag values to the IssueData struct
-func applyFlagOverrides(data *IssueData) {
+func applyFlagOverrides(data *IssueData) error {
 	// Apply string field overrides
 	if flagTitle != "" {
 		data.Title = flagTitle
@@ -405,24 +439,32 @@ func applyFlagOverrides(data *IssueData) {
 
 	// Apply FixDescription override
 	if flagFixDescription != "" {
-		data.FixDescription = parseFixDescription(flagFixDescription)
+		fixDescription, err := parseFixDescription(flagFixDescription)
+		if err != nil {
+			return err
+		}
+		data.FixDescription = fixDescription
 	}
+
+	return nil
 }
 
 func generateSingleExample(issueType string) {
-	fmt.Printf("Generating example for type: %s\n", issueType)
-
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		fmt.Printf("Error creating output directory: %v\n", err)
-		return
+	// Keep stdout clean for the rendered markdown when writing to it
+	status := os.Stdout
+	if toStdout {
+		status = os.Stderr
 	}
 
+	fmt.Fprintf(status, "Generating example for type: %s\n", issueType)
+
 	// Create default IssueData with example values
 	data := createDefaultIssueData(issueType)
 
 	// Apply flag overrides to the default data
-	applyFlagOverrides(&data)
+	if err := applyFlagOverrides(&data); err != nil {
+		cmdutil.Fatalf("Invalid flags: %v", err)
+	}
 
 	// Determine template and output filename based on issue type
 	var templatePath, outputFilename string
@@ -440,12 +482,33 @@ func generateSingleExample(issueType string) {
 		templatePath = "templates/feature.md.tmpl"
 		outputFilename = "feature-example.md"
 	default:
-		fmt.Printf("Unknown issue type: %s. Available types: epic, task, bug, feature\n", issueType)
+		fmt.Fprintf(status, "Unknown issue type: %s. Available types: epic, task, bug, feature\n", issueType)
+		return
+	}
+
+	if toStdout {
+		tmpl, err := loadTemplate(templatePath)
+		if err != nil {
+			fmt.Fprintf(status, "Error: %v\n", err)
+			return
+		}
+		if err := executeTemplate(tmpl, os.Stdout, data); err != nil {
+			fmt.Fprintf(status, "Error executing template %s: %v\n", templatePath, err)
+		}
+		return
+	}
+
+	// Create output directory if it doesn't exist
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		fmt.Printf("Error creating output directory: %v\n", err)
 		return
 	}
 
 	// Generate the markdown file using the populated data
 	generateFromTemplate(templatePath, outputFilename, data)
+	if len(skippedFiles) > 0 {
+		return
+	}
 
 	fmt.Printf("Example file for %s generated successfully in %s/\n", issueType, outputDir)
 }
@@ -584,10 +647,7 @@ func generateBugExamples() {
 			"Rate limiting configuration too restrictive",
 			"Email queue processing stuck",
 		},
-		FixDescription: []struct {
-			Index       int
-			Description string
-		}{
+		FixDescription: []FixDescriptionItem{
 			{0, "Update email service API credentials"},
 			{1, "Adjust rate limiting configuration"},
 			{2, "Implement email queue monitoring"},
@@ -739,37 +799,33 @@ func generateFeatureExamples() {
 }
 
 func generateFromTemplate(templatePath, outputFilename string, data IssueData) {
-	// Create custom template functions
-	funcMap := template.FuncMap{
-		"add": func(a, b int) int {
-			return a + b
-		},
+	tmpl, err := loadTemplate(templatePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
 
-	// Read template from embedded filesystem or local filesystem
-	var tmplContent []byte
-	var err error
-
-	// Try embedded filesystem first
-	tmplContent, err = templatesFS.ReadFile(templatePath)
-	if err != nil {
-		// Fall back to local filesystem
-		tmplContent, err = os.ReadFile(templatePath)
-		if err != nil {
-			fmt.Printf("Error reading template %s: %v\n", templatePath, err)
+	dir := outputDir
+	if dirPerType {
+		dir = filepath.Join(outputDir, typeDir(templatePath))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Printf("Error creating output directory: %v\n", err)
 			return
 		}
 	}
 
-	// Parse the template
-	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcMap).Parse(string(tmplContent))
-	if err != nil {
-		fmt.Printf("Error parsing template %s: %v\n", templatePath, err)
-		return
+	// Don't clobber existing (possibly hand-edited) files unless asked to
+	fullPath := filepath.Join(dir, outputFilename)
+	if !overwrite && fileExists(fullPath) {
+		if !unique {
+			fmt.Printf("Skipped: %s already exists (use --overwrite to replace it or --unique to write alongside it)\n", fullPath)
+			skippedFiles = append(skippedFiles, fullPath)
+			return
+		}
+		fullPath = uniquePath(fullPath)
 	}
 
 	// Create the output file
-	fullPath := filepath.Join(outputDir, outputFilename)
 	file, err := os.Create(fullPath)
 	if err != nil {
 		fmt.Printf("Error creating file %s: %v\n", fullPath, err)
@@ -778,7 +834,7 @@ func generateFromTemplate(templatePath, outputFilename string, data IssueData) {
 	defer file.Close()
 
 	// Execute the template
-	err = tmpl.Execute(file, data)
+	err = executeTemplate(tmpl, file, data)
 	if err != nil {
 		fmt.Printf("Error executing template for %s: %v\n", fullPath, err)
 		return
@@ -787,16 +843,98 @@ func generateFromTemplate(templatePath, outputFilename string, data IssueData) {
 	fmt.Printf("Created: %s\n", fullPath)
 }
 
-func listGeneratedFiles() {
-	files, err := os.ReadDir(outputDir)
+// typeDir returns the --dir-per-type subdirectory for files rendered from templatePath.
+func typeDir(templatePath string) string {
+	name := filepath.Base(templatePath)
+	switch {
+	case strings.HasPrefix(name, "epic"):
+		return "epics"
+	case strings.HasPrefix(name, "task"):
+		return "tasks"
+	case strings.HasPrefix(name, "bug"):
+		return "bugs"
+	case strings.HasPrefix(name, "feature"):
+		return "features"
+	}
+	return "other"
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// uniquePath returns path with the first numeric suffix (name-1.md, name-2.md, ...) that doesn't exist yet.
+func uniquePath(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if !fileExists(candidate) {
+			return candidate
+		}
+	}
+}
+
+// loadTemplate reads and parses a template from the embedded filesystem, falling back to the local filesystem.
+func loadTemplate(templatePath string) (*template.Template, error) {
+	// Create custom template functions
+	funcMap := template.FuncMap{
+		"add": func(a, b int) int {
+			return a + b
+		},
+	}
+
+	// Try embedded filesystem first
+	tmplContent, err := templatesFS.ReadFile(templatePath)
 	if err != nil {
-		fmt.Printf("Error reading output directory: %v\n", err)
-		return
+		// Fall back to local filesystem
+		tmplContent, err = os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading template %s: %w", templatePath, err)
+		}
+	}
+
+	// Parse the template
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcMap).Parse(string(tmplContent))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", templatePath, err)
 	}
+	return tmpl, nil
+}
+
+// executeTemplate renders data with tmpl into w.
+func executeTemplate(tmpl *template.Template, w io.Writer, data IssueData) error {
+	// Render fix steps in the order of their index, regardless of how they were supplied
+	data.FixDescription = sortedFixDescription(data.FixDescription)
+	return tmpl.Execute(w, data)
+}
+
+// sortedFixDescription returns a copy of items ordered by Index.
+func sortedFixDescription(items []FixDescriptionItem) []FixDescriptionItem {
+	sorted := append([]FixDescriptionItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+	return sorted
+}
 
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".md") {
-			fmt.Printf("  - %s\n", file.Name())
+func listGeneratedFiles() {
+	err := filepath.WalkDir(outputDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && path != outputDir && !dirPerType {
+			return filepath.SkipDir
 		}
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			rel, _ := filepath.Rel(outputDir, path)
+			fmt.Printf("  - %s\n", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error reading output directory: %v\n", err)
 	}
 }
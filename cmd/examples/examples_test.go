@@ -0,0 +1,79 @@
+package examples
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFixDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []FixDescriptionItem
+		wantErr string
+	}{
+		{
+			name:  "out-of-order indices are sorted",
+			input: "2:Deploy; 0:Patch ;1:Test",
+			want:  []FixDescriptionItem{{0, "Patch"}, {1, "Test"}, {2, "Deploy"}},
+		},
+		{
+			name:  "empty segments are ignored",
+			input: ";0:Patch;; ;",
+			want:  []FixDescriptionItem{{0, "Patch"}},
+		},
+		{name: "empty input", input: ""},
+		{name: "index is not a number", input: "abc:foo", wantErr: `index "abc" is not a number`},
+		{name: "missing colon", input: "0:Patch;Test", wantErr: "expected 'index:description'"},
+		{name: "negative index", input: "-1:Patch", wantErr: "must not be negative"},
+		{name: "duplicate index", input: "0:Patch;0:Test", wantErr: "duplicate index 0"},
+		{name: "empty description", input: "0: ", wantErr: "description is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFixDescription(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseFixDescription(%q) error = %v, want one containing %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFixDescription(%q): %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFixDescription(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateSingleExampleRejectsBadFixDescription runs the example generator in a child
+// process because a bad --fix-description ends it through cmdutil.Fatalf.
+func TestGenerateSingleExampleRejectsBadFixDescription(t *testing.T) {
+	if os.Getenv("GIM_EXAMPLES_CHILD") == "1" {
+		toStdout = true
+		flagFixDescription = "abc:foo"
+		generateSingleExample("bug")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestGenerateSingleExampleRejectsBadFixDescription$")
+	cmd.Env = append(os.Environ(), "GIM_EXAMPLES_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("child exited with %v, want status 1; output:\n%s", err, out)
+	}
+	if !strings.Contains(string(out), `Invalid flags: invalid fix description segment "abc:foo"`) {
+		t.Errorf("output = %q, want the --fix-description error", out)
+	}
+	if strings.Contains(string(out), "## ") {
+		t.Errorf("output = %q, want no rendered example", out)
+	}
+}
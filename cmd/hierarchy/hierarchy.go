@@ -0,0 +1,171 @@
+package hierarchy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var folder string
+var owner string
+var repo string
+var repair bool
+var failOnEmpty bool
+
+var Cmd = &cobra.Command{
+	Use:   "verify-hierarchy",
+	Short: "Check that GitHub's parent/child links match the issue files",
+	Long:  "For every issue file with an id, compare the issue's parent on GitHub with the file's parent: and report each relationship as ok, a missing link, a wrong parent or an unexpected link. With --repair, fix GitHub to match the files.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		cmdutil.SetErrorTarget(owner, repo)
+		if owner == "" || repo == "" {
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+		}
+
+		issues, err := issuemanager.ReadIssueFiles(folder)
+		if err != nil {
+			cmdutil.Fatalf("Error reading issue files: %v", err)
+		}
+		cmdutil.ExitIfNoIssueFiles(len(issues), folder, false, failOnEmpty)
+
+		// Parents that are local files with an id are compared by number, others by title
+		localNumbers := make(map[string]int64)
+		for _, issue := range issues {
+			if number, err := strconv.ParseInt(strings.TrimSpace(issue.Id), 10, 64); err == nil {
+				localNumbers[strings.ToLower(strings.TrimSpace(issue.Title))] = number
+			}
+		}
+
+		checked, failed := 0, 0
+		for _, issue := range issues {
+			id := strings.TrimSpace(issue.Id)
+			if id == "" {
+				continue
+			}
+			file := filepath.Join(issue.Path, issue.FileName)
+			number, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				fmt.Printf("FAIL %s: invalid id %q\n", file, id)
+				failed++
+				continue
+			}
+
+			details, err := client.GetIssue(ctx, owner, repo, number)
+			if err != nil {
+				fmt.Printf("FAIL %s: #%d: %v\n", file, number, err)
+				failed++
+				continue
+			}
+			checked++
+
+			want := strings.TrimSpace(issue.Parent)
+			problem := relationshipProblem(want, details.Parent, localNumbers)
+			if problem == "" {
+				if want != "" {
+					fmt.Printf("ok   #%d %q -> parent %q\n", number, issue.Title, want)
+				}
+				continue
+			}
+
+			failed++
+			fmt.Printf("FAIL #%d %q: %s\n", number, issue.Title, problem)
+			if repair && repairRelationship(ctx, client, issue, details) {
+				failed--
+			}
+		}
+
+		fmt.Printf("%d issue(s) checked, %d problem(s)\n", checked, failed)
+		if failed > 0 {
+			cmdutil.Failf("%d issue(s) with hierarchy problems", failed)
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().BoolVar(&repair, "repair", false, "Link, relink or unlink issues on GitHub so their parents match the files")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+}
+
+// relationshipProblem describes how the GitHub parent differs from the wanted parent title, or
+// returns "" when they match.
+func relationshipProblem(want string, actual *ghclient.IssueRef, localNumbers map[string]int64) string {
+	switch {
+	case want == "" && actual == nil:
+		return ""
+	case want == "":
+		return fmt.Sprintf("unexpected link: GitHub parent is #%d %q but the file has no parent", actual.Number, actual.Title)
+	case actual == nil:
+		return fmt.Sprintf("missing link: parent %q is not linked on GitHub", want)
+	}
+
+	if number, ok := localNumbers[strings.ToLower(want)]; ok {
+		if number == actual.Number {
+			return ""
+		}
+	} else if strings.EqualFold(strings.TrimSpace(actual.Title), want) {
+		return ""
+	}
+	return fmt.Sprintf("wrong parent: GitHub parent is #%d %q, file says %q", actual.Number, actual.Title, want)
+}
+
+// repairRelationship makes GitHub's parent of issue match its file and reports whether it succeeded.
+func repairRelationship(ctx context.Context, client *ghclient.Client, issue issuemanager.Issue, details *ghclient.IssueDetails) bool {
+	var err error
+	if strings.TrimSpace(issue.Parent) == "" {
+		err = client.RemoveParentRelationshipByID(ctx, details.Parent.ID, details.ID)
+	} else {
+		err = client.UpdateParentRelationship(ctx, owner, repo, details.ID, issue.Parent)
+	}
+	if err != nil {
+		logger.Error("Failed to repair parent relationship", "issue", issue.Title, "error", err)
+		fmt.Printf("     repair failed: %v\n", err)
+		return false
+	}
+	fmt.Println("     repaired")
+	return true
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github-issue-manager/cmd/cmdutil"
 	"github-issue-manager/pkg/git"
 	ghclient "github-issue-manager/pkg/github"
 	"github-issue-manager/pkg/logger"
@@ -15,27 +17,32 @@ import (
 
 var owner string
 var repo string
+var format string
 
 var Cmd = &cobra.Command{
 	Use:   "info",
 	Short: "Display information about the GitHub repository",
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		client := authenticate(ctx)
 
-		// Infer owner and repo from .git/config if not provided via flags
-		inferredOwner, inferredRepo := git.InferOwnerRepoFromGit()
+		if format != "json" && format != "markdown" {
+			cmdutil.Fatalf("Unsupported format %q (expected json or markdown)", format)
+		}
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
 		if owner == "" {
 			owner = inferredOwner
 		}
 		if repo == "" {
 			repo = inferredRepo
 		}
+		cmdutil.SetErrorTarget(owner, repo)
 
 		if owner == "" || repo == "" {
 			logger.Error("Owner and repository name must be specified either via flags or inferred from .git/config")
-			fmt.Fprintln(os.Stderr, "Owner and repository name must be specified either via flags or inferred from .git/config")
-			os.Exit(1)
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
 		}
 
 		logger.Debug("Using owner and repo", "owner", owner, "repo", repo)
@@ -43,22 +50,28 @@ var Cmd = &cobra.Command{
 		repoInfo, err := client.GetRepositoryInfo(ctx, owner, repo)
 		if err != nil {
 			logger.Error("Failed to get repository info", "error", err)
-			fmt.Fprintf(os.Stderr, "Failed to get repository info: %v\n", err)
-			os.Exit(1)
+			cmdutil.Fatalf("Failed to get repository info: %v", err)
 		}
 
 		// Handle edge case: empty repository info
 		if repoInfo == nil {
 			logger.Warn("Received empty repository info")
-			fmt.Fprintln(os.Stderr, "Received empty repository info from GitHub")
-			os.Exit(1)
+			cmdutil.Fatalf("Received empty repository info from GitHub")
+		}
+
+		if repoInfo.IsArchived {
+			logger.Warn("Repository is archived; issues can't be created in it", "owner", owner, "repo", repo)
+		}
+
+		if format == "markdown" {
+			fmt.Print(markdown(owner, repo, repoInfo))
+			return
 		}
 
 		jsonData, err := json.MarshalIndent(repoInfo, "", "  ")
 		if err != nil {
 			logger.Error("Failed to marshal repository info to JSON", "error", err)
-			fmt.Fprintf(os.Stderr, "Failed to format repository info as JSON: %v\n", err)
-			os.Exit(1)
+			cmdutil.Fatalf("Failed to format repository info as JSON: %v", err)
 		}
 
 		fmt.Println(string(jsonData))
@@ -68,24 +81,49 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
 	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().StringVar(&format, "format", "json", "Output format (json, markdown)")
+}
+
+// markdown renders the repository facts as a markdown table for pasting into an issue or PR.
+func markdown(owner, repo string, info *ghclient.RepositoryInfo) string {
+	var labels, types, fields []string
+	for _, label := range info.Labels {
+		labels = append(labels, label.Name)
+	}
+	for _, issueType := range info.IssueTypes {
+		types = append(types, issueType.Name)
+	}
+	for _, field := range info.ProjectFields {
+		fields = append(fields, field.Name)
+	}
+
+	rows := [][]string{
+		{"Repository", owner + "/" + repo},
+		{"Primary language", info.PrimaryLanguage},
+		{"Topics", strings.Join(info.Topics, ", ")},
+		{"License", info.License},
+		{"Archived", strconv.FormatBool(info.IsArchived)},
+		{"Labels", strings.Join(labels, ", ")},
+		{"Issue types", strings.Join(types, ", ")},
+		{"Project fields", strings.Join(fields, ", ")},
+	}
+	return cmdutil.MarkdownTable([]string{"Fact", "Value"}, rows)
 }
 
 func authenticate(ctx context.Context) *ghclient.Client {
-	token := os.Getenv("GITHUB_TOKEN")
+	token := ghclient.TokenFromEnv()
 	if token == "" {
 		// Try to read token from hosts file
 		hostsToken, err := ghclient.ReadTokenFromHostsFile()
 		if err != nil {
 			logger.Error("Failed to read token from hosts file", "error", err)
-			fmt.Fprintf(os.Stderr, "Failed to read token from hosts file: %v\n", err)
-			os.Exit(1)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
 		}
 		if hostsToken != "" {
 			token = hostsToken
 		} else {
 			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
-			fmt.Fprintln(os.Stderr, "GITHUB_TOKEN environment variable and hosts file token are both not set")
-			os.Exit(1)
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
 		}
 	}
 
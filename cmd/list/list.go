@@ -1,23 +1,62 @@
 package list
 
 import (
+	"context"
 	"fmt"
-	mdparser "github-issue-manager/pkg/mdparser"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+	mdparser "github-issue-manager/pkg/mdparser"
 )
 
 var folder string
+var remote bool
+var owner string
+var repo string
+var failOnEmpty bool
+var expandEmoji bool
+var recursive bool
 
 var Cmd = &cobra.Command{
 	Use:   "list",
 	Short: "List GitHub issues",
 	Run: func(cmd *cobra.Command, args []string) {
-		files, err := mdparser.ListMarkdownFiles(folder)
+		var ctx context.Context
+		var client *ghclient.Client
+		if remote {
+			ctx = cmd.Context()
+			client = authenticate(ctx)
+
+			// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+			inferredOwner, inferredRepo := git.InferOwnerRepo()
+			if owner == "" {
+				owner = inferredOwner
+			}
+			if repo == "" {
+				repo = inferredRepo
+			}
+			cmdutil.SetErrorTarget(owner, repo)
+			if owner == "" || repo == "" {
+				cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+			}
+		}
+
+		listFiles := mdparser.ListMarkdownFiles
+		if recursive {
+			listFiles = mdparser.ListMarkdownFilesRecursive
+		}
+		files, err := listFiles(folder)
 		if err != nil {
-			fmt.Printf("Error reading folder '%s': %v\n", folder, err)
-			return
+			cmdutil.Fatalf("Error reading folder '%s': %v", folder, err)
 		}
+		cmdutil.ExitIfNoIssueFiles(len(files), folder, recursive, failOnEmpty)
 		for _, file := range files {
 			fmt.Println(file)
 			frontMatter, err := mdparser.ParseFrontMatter(file)
@@ -29,12 +68,118 @@ var Cmd = &cobra.Command{
 				if key == "body" {
 					continue
 				}
+				if expandEmoji {
+					value = issuemanager.ExpandEmoji(value)
+				}
 				fmt.Printf("  %s: %s\n", key, value)
 			}
+
+			if remote {
+				printRemote(ctx, client, frontMatter)
+			}
 		}
 	},
 }
 
 func init() {
 	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
+	Cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Also list issue files in subdirectories of the folder (e.g. one per epic)")
+	Cmd.Flags().BoolVar(&remote, "remote", false, "Fetch the GitHub type, state and labels of issues with an id and highlight drift")
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name (used with --remote)")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name (used with --remote)")
+	Cmd.Flags().BoolVar(&expandEmoji, "expand-emoji", false, "Show GitHub emoji shortcodes (e.g. :rocket:) as unicode")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+}
+
+// printRemote prints the GitHub-side type, state and labels of an issue next to the local values.
+func printRemote(ctx context.Context, client *ghclient.Client, frontMatter map[string]string) {
+	id := strings.TrimSpace(frontMatter["id"])
+	if id == "" {
+		fmt.Println("  remote: (not created yet)")
+		return
+	}
+
+	number, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		fmt.Printf("  remote: invalid id %q: %v\n", id, err)
+		return
+	}
+
+	details, err := client.GetIssue(ctx, owner, repo, number)
+	if err != nil {
+		logger.Debug("Failed to fetch remote issue", "number", number, "error", err)
+		fmt.Printf("  remote: error fetching #%d: %v\n", number, err)
+		return
+	}
+
+	localType := strings.TrimSpace(frontMatter["type"])
+	typeDrift := ""
+	if !strings.EqualFold(localType, details.IssueType) {
+		typeDrift = fmt.Sprintf("  [DRIFT: local %q]", localType)
+	}
+
+	localLabels := issuemanager.SplitLabels(frontMatter["labels"])
+	labelDrift := ""
+	if !sameLabels(localLabels, details.Labels) {
+		labelDrift = fmt.Sprintf("  [DRIFT: local %q]", strings.Join(localLabels, ", "))
+	}
+
+	fmt.Printf("  remote #%d (%s)\n", details.Number, details.URL)
+	fmt.Printf("    state: %s%s\n", details.State, snapshotDrift(frontMatter["state"], details.State))
+	fmt.Printf("    author: %s%s\n", details.Author, snapshotDrift(frontMatter["author"], details.Author))
+	fmt.Printf("    created_at: %s%s\n", details.CreatedAt, snapshotDrift(frontMatter["created_at"], details.CreatedAt))
+	fmt.Printf("    updated_at: %s%s\n", details.UpdatedAt, snapshotDrift(frontMatter["updated_at"], details.UpdatedAt))
+	if details.Closed {
+		fmt.Printf("    closed_at: %s%s\n", details.ClosedAt, snapshotDrift(frontMatter["closed_at"], details.ClosedAt))
+	}
+	fmt.Printf("    type: %s%s\n", details.IssueType, typeDrift)
+	fmt.Printf("    labels: %s%s\n", strings.Join(details.Labels, ", "), labelDrift)
+}
+
+// snapshotDrift returns a drift marker when a read-only snapshot field recorded in the file differs
+// from GitHub. Fields the file doesn't record never drift.
+func snapshotDrift(local, remote string) string {
+	local = strings.TrimSpace(local)
+	if local == "" || strings.EqualFold(local, remote) {
+		return ""
+	}
+	return fmt.Sprintf("  [DRIFT: local %q]", local)
+}
+
+// sameLabels reports whether both label sets contain the same names, ignoring case and order.
+func sameLabels(a, b []string) bool {
+	set := make(map[string]int)
+	for _, label := range a {
+		set[strings.ToLower(strings.TrimSpace(label))]++
+	}
+	for _, label := range b {
+		set[strings.ToLower(strings.TrimSpace(label))]--
+	}
+	for _, count := range set {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
 }
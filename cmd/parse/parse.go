@@ -0,0 +1,60 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+)
+
+var titleFromH1 bool
+var bodyTemplates string
+var bodyTemplatesOverwrite bool
+var bodyTrim string
+var folder string
+
+var Cmd = &cobra.Command{
+	Use:   "parse <file>",
+	Short: "Print the issue parsed from a markdown file as JSON",
+	Long:  "Parse a single issue file the same way create does (front matter, folder defaults, body) and print the resulting issue as JSON, to debug why a field didn't take effect without touching GitHub.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		trim, err := issuemanager.ParseBodyTrim(bodyTrim)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --body-trim: %v", err)
+		}
+		issue, err := issuemanager.ReadIssueFile(args[0], folder, trim)
+		if err != nil {
+			cmdutil.Fatalf("Error reading issue file: %v", err)
+		}
+
+		issues := []issuemanager.Issue{issue}
+		if titleFromH1 {
+			if err := issuemanager.TitleFromH1(issues); err != nil {
+				cmdutil.Fatalf("Error reading title: %v", err)
+			}
+		}
+		if bodyTemplates != "" {
+			if err := issuemanager.ApplyBodyTemplates(issues, bodyTemplates, bodyTemplatesOverwrite); err != nil {
+				cmdutil.Fatalf("Error rendering body templates: %v", err)
+			}
+		}
+
+		jsonData, err := json.MarshalIndent(issues[0], "", "  ")
+		if err != nil {
+			cmdutil.Fatalf("Failed to marshal issue to JSON: %v", err)
+		}
+		fmt.Println(string(jsonData))
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&folder, "folder", "f", "", "Issue folder the file is read from with create -R, so the defaults.yaml files from there down to the file's folder apply (default: the file's folder)")
+	Cmd.Flags().BoolVar(&titleFromH1, "title-from-h1", false, "Use the first # heading of the body (removed from the body) as the title if the file has no title:")
+	Cmd.Flags().StringVar(&bodyTrim, "body-trim", "edges", "Whitespace trimmed from the body: none, edges (leading/trailing blank lines) or all (also every line's indentation)")
+	Cmd.Flags().StringVar(&bodyTemplates, "body-templates", "", "Folder of per-type body templates (e.g. bug.md.tmpl) rendered from the front matter if the file has no body")
+	Cmd.Flags().BoolVar(&bodyTemplatesOverwrite, "body-templates-overwrite", false, "Also render the body template if the file has a body (available to the template as .Body)")
+}
@@ -0,0 +1,189 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	"github-issue-manager/pkg/logger"
+)
+
+var owner string
+var jsonOutput bool
+var project string
+var query string
+var status string
+var limit int
+var dryRun bool
+
+var Cmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Work with GitHub projects",
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the projects of an organization or user",
+	Long:  "List the projects (v2) of an organization or user, to find the exact title to use in the project front matter field.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		if owner == "" {
+			// Infer owner from GitHub Actions or .git/config if not provided via flags
+			owner, _ = git.InferOwnerRepo()
+		}
+		cmdutil.SetErrorTarget(owner, "")
+		if owner == "" {
+			logger.Error("Owner must be specified either via flags or inferred from .git/config")
+			cmdutil.Fatalf("Owner must be specified either via flags or inferred from .git/config")
+		}
+
+		projects, err := client.ListProjects(ctx, owner)
+		if err != nil {
+			logger.Error("Failed to list projects", "error", err)
+			cmdutil.Fatalf("Failed to list projects: %v", err)
+		}
+
+		if jsonOutput {
+			jsonData, err := json.MarshalIndent(projects, "", "  ")
+			if err != nil {
+				cmdutil.Fatalf("Failed to format projects as JSON: %v", err)
+			}
+			fmt.Println(string(jsonData))
+			return
+		}
+
+		if len(projects) == 0 {
+			fmt.Printf("No projects found for %s.\n", owner)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NUMBER\tTITLE\tPUBLIC\tURL")
+		for _, project := range projects {
+			fmt.Fprintf(w, "%d\t%s\t%t\t%s\n", project.Number, project.Title, project.Public, project.URL)
+		}
+		w.Flush()
+	},
+}
+
+var addFromSearchCmd = &cobra.Command{
+	Use:   "add-from-search",
+	Short: "Add every issue matching a search query to a project",
+	Long:  "Run a GitHub issue search and add every matching issue to a project (v2), optionally setting its Status, e.g. to put all open bugs on a board.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		if owner == "" {
+			// Infer owner from GitHub Actions or .git/config if not provided via flags
+			owner, _ = git.InferOwnerRepo()
+		}
+		cmdutil.SetErrorTarget(owner, "")
+		if owner == "" {
+			logger.Error("Owner must be specified either via flags or inferred from .git/config")
+			cmdutil.Fatalf("Owner must be specified either via flags or inferred from .git/config")
+		}
+		if strings.TrimSpace(project) == "" || strings.TrimSpace(query) == "" {
+			cmdutil.Fatalf("Both --project and --query are required")
+		}
+
+		searchQuery := query
+		if !strings.Contains(searchQuery, "is:issue") {
+			searchQuery = "is:issue " + searchQuery
+		}
+		logger.Debug("Searching issues", "query", searchQuery)
+
+		issues, err := client.SearchIssues(ctx, searchQuery, limit)
+		if err != nil {
+			logger.Error("Failed to search issues", "error", err)
+			cmdutil.Fatalf("Failed to search issues: %v", err)
+		}
+		if len(issues) == 0 {
+			fmt.Println("No issues found.")
+			return
+		}
+
+		projectNodeID, err := client.ResolveProjectID(ctx, owner, project)
+		if err != nil {
+			logger.Error("Failed to resolve project", "project", project, "error", err)
+			cmdutil.Fatalf("Failed to resolve project %q: %v", project, err)
+		}
+
+		added, failed := 0, 0
+		for _, issue := range issues {
+			ref := fmt.Sprintf("%s/%s#%d", issue.Owner, issue.Repo, issue.Number)
+			if dryRun {
+				fmt.Printf("Would add %s %s to project '%s'\n", ref, issue.Title, project)
+				continue
+			}
+
+			itemID, err := client.AddIssueToProject(ctx, issue.ID, projectNodeID)
+			if err != nil {
+				logger.Error("Failed to add issue to project", "issue", ref, "error", err)
+				failed++
+				continue
+			}
+			if status != "" {
+				if err := client.SetProjectItemFieldValue(ctx, projectNodeID, itemID, "Status", status); err != nil {
+					logger.Warn("Failed to set project status", "issue", ref, "status", status, "error", err)
+				}
+			}
+			fmt.Printf("Added %s %s to project '%s'\n", ref, issue.Title, project)
+			added++
+		}
+
+		if dryRun {
+			fmt.Printf("Dry run: %d issue(s) would be added\n", len(issues))
+			return
+		}
+		fmt.Printf("Added %d issue(s), %d failed\n", added, failed)
+		if failed > 0 {
+			cmdutil.Failf("Failed to add %d issue(s) to the project", failed)
+		}
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&owner, "owner", "o", "", "Organization or user login")
+	listCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output projects as JSON")
+	Cmd.AddCommand(listCmd)
+
+	addFromSearchCmd.Flags().StringVarP(&owner, "owner", "o", "", "Organization or user login owning the project")
+	addFromSearchCmd.Flags().StringVarP(&project, "project", "p", "", "Title of the project to add issues to")
+	addFromSearchCmd.Flags().StringVarP(&query, "query", "q", "", "GitHub search query, e.g. \"repo:my-org/app is:open label:bug\"")
+	addFromSearchCmd.Flags().StringVar(&status, "status", "", "Status option to set on every added issue")
+	addFromSearchCmd.Flags().IntVarP(&limit, "limit", "n", 500, "Maximum number of issues to add")
+	addFromSearchCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "List the issues that would be added without changing the project")
+	Cmd.AddCommand(addFromSearchCmd)
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
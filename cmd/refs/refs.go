@@ -0,0 +1,121 @@
+package refs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var folder string
+var owner string
+var repo string
+var closeRefs bool
+var dryRun bool
+var failOnEmpty bool
+
+var Cmd = &cobra.Command{
+	Use:   "refs",
+	Short: "Check or close issues referenced with \"Closes #N\" in issue bodies",
+	Long:  "Scan issue bodies for closing references (\"Closes #N\", \"Fixes #N\", \"Resolves #N\") and check that every referenced issue exists. With --close (e.g. once the work has merged), close the referenced issues that are still open.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		cmdutil.SetErrorTarget(owner, repo)
+		if owner == "" || repo == "" {
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+		}
+
+		issues, err := issuemanager.ReadIssueFiles(folder)
+		if err != nil {
+			cmdutil.Fatalf("Error reading issue files: %v", err)
+		}
+		cmdutil.ExitIfNoIssueFiles(len(issues), folder, false, failOnEmpty)
+
+		references, missing, closed := 0, 0, 0
+		for _, issue := range issues {
+			file := filepath.Join(issue.Path, issue.FileName)
+			for _, number := range issuemanager.ClosingReferences(issue.Body) {
+				references++
+				details, err := client.GetIssue(ctx, owner, repo, number)
+				if err != nil {
+					logger.Debug("Failed to fetch referenced issue", "number", number, "error", err)
+					fmt.Printf("%s: #%d: not found (%v)\n", file, number, err)
+					missing++
+					continue
+				}
+				if !closeRefs {
+					fmt.Printf("%s: #%d %q (%s)\n", file, number, details.Title, details.State)
+					continue
+				}
+				if details.Closed {
+					fmt.Printf("%s: #%d already closed\n", file, number)
+					continue
+				}
+				if dryRun {
+					fmt.Printf("%s: would close #%d %q\n", file, number, details.Title)
+					continue
+				}
+				if err := client.CloseIssue(ctx, details.ID); err != nil {
+					logger.Error("Failed to close issue", "number", number, "error", err)
+					fmt.Printf("%s: failed to close #%d: %v\n", file, number, err)
+					missing++
+					continue
+				}
+				fmt.Printf("%s: closed #%d %q\n", file, number, details.Title)
+				closed++
+			}
+		}
+
+		fmt.Printf("%d reference(s) checked, %d closed, %d problem(s)\n", references, closed, missing)
+		if missing > 0 {
+			cmdutil.Failf("%d problem(s) with issue references", missing)
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().BoolVar(&closeRefs, "close", false, "Close referenced issues that are still open")
+	Cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "With --close, show which issues would be closed without closing them")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	"github-issue-manager/pkg/logger"
+)
+
+var owner string
+var repo string
+var query string
+var labels []string
+var state string
+var author string
+var assignee string
+var allRepos bool
+var limit int
+var format string
+var updatedSince string
+
+var Cmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search GitHub issues",
+	Long:  "Search GitHub issues using a raw search query and/or structured filters. Results are scoped to the resolved repository unless --all-repos is set.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		if format != "table" && format != "json" {
+			cmdutil.Fatalf("Unsupported format %q (expected table or json)", format)
+		}
+
+		if !allRepos {
+			// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+			inferredOwner, inferredRepo := git.InferOwnerRepo()
+			if owner == "" {
+				owner = inferredOwner
+			}
+			if repo == "" {
+				repo = inferredRepo
+			}
+			cmdutil.SetErrorTarget(owner, repo)
+
+			if owner == "" || repo == "" {
+				logger.Error("Owner and repository name must be specified either via flags or inferred from .git/config")
+				cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config (or use --all-repos)")
+			}
+		}
+
+		searchQuery, err := buildQuery(time.Now())
+		if err != nil {
+			cmdutil.Fatalf("Invalid --updated-since: %v", err)
+		}
+		logger.Debug("Searching issues", "query", searchQuery)
+
+		issues, err := client.SearchIssues(ctx, searchQuery, limit)
+		if err != nil {
+			logger.Error("Failed to search issues", "error", err)
+			cmdutil.Fatalf("Failed to search issues: %v", err)
+		}
+
+		if format == "json" {
+			jsonData, err := json.MarshalIndent(issues, "", "  ")
+			if err != nil {
+				cmdutil.Fatalf("Failed to format search results as JSON: %v", err)
+			}
+			fmt.Println(string(jsonData))
+			return
+		}
+
+		if len(issues) == 0 {
+			fmt.Println("No issues found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NUMBER\tSTATE\tTITLE\tLABELS\tAUTHOR")
+		for _, issue := range issues {
+			number := fmt.Sprintf("#%d", issue.Number)
+			if allRepos {
+				number = fmt.Sprintf("%s/%s#%d", issue.Owner, issue.Repo, issue.Number)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", number, issue.State, issue.Title, strings.Join(issue.Labels, ", "), issue.Author)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().StringVarP(&query, "query", "q", "", "Raw GitHub search query")
+	Cmd.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "Filter by label (can be used multiple times)")
+	Cmd.Flags().StringVarP(&state, "state", "s", "", "Filter by state (open, closed)")
+	Cmd.Flags().StringVar(&author, "author", "", "Filter by author login")
+	Cmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee login")
+	Cmd.Flags().BoolVar(&allRepos, "all-repos", false, "Don't scope the search to the resolved repository")
+	Cmd.Flags().IntVarP(&limit, "limit", "n", 30, "Maximum number of results")
+	Cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+	Cmd.Flags().StringVar(&updatedSince, "updated-since", "", "Only issues updated within this duration (e.g. 7d, 36h)")
+}
+
+// buildQuery combines the raw query and structured filters into a GitHub search query.
+// now anchors the --updated-since window.
+func buildQuery(now time.Time) (string, error) {
+	parts := []string{"is:issue"}
+	if !allRepos {
+		parts = append(parts, fmt.Sprintf("repo:%s/%s", owner, repo))
+	}
+	for _, label := range labels {
+		parts = append(parts, fmt.Sprintf("label:%q", label))
+	}
+	if state != "" {
+		parts = append(parts, "is:"+strings.ToLower(state))
+	}
+	if author != "" {
+		parts = append(parts, "author:"+author)
+	}
+	if assignee != "" {
+		parts = append(parts, "assignee:"+assignee)
+	}
+	if updatedSince != "" {
+		window, err := cmdutil.ParseDuration(updatedSince)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, "updated:>="+now.Add(-window).UTC().Format(time.RFC3339))
+	}
+	if query != "" {
+		parts = append(parts, query)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
@@ -0,0 +1,124 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var owner string
+var repo string
+var issueNumber int64
+var target string
+var file string
+var dropType bool
+
+var Cmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Transfer an issue to another repository",
+	Long:  "Transfer an issue to another repository and optionally update the id in its local markdown file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := authenticate(ctx)
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		cmdutil.SetErrorTarget(owner, repo)
+		if owner == "" || repo == "" {
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+		}
+
+		targetOwner, targetRepo, ok := strings.Cut(target, "/")
+		if !ok || targetOwner == "" || targetRepo == "" {
+			cmdutil.Fatalf("Invalid --to %q (expected owner/repo)", target)
+		}
+
+		details, err := client.GetIssue(ctx, owner, repo, issueNumber)
+		if err != nil {
+			cmdutil.Fatalf("Failed to get issue #%d: %v", issueNumber, err)
+		}
+
+		// The issue type only survives the transfer if the target repository has a type with the same name
+		if details.IssueType != "" {
+			if _, err := client.ResolveIssueTypeID(ctx, targetOwner, targetRepo, details.IssueType); err != nil {
+				if !dropType {
+					cmdutil.Fatalf("Target repository %s does not accept issue type %q: %v\nRe-run with --drop-type to transfer without the type.", target, details.IssueType, err)
+				}
+				logger.Warn("Issue type is not available in the target repository and will be dropped", "type", details.IssueType, "target", target)
+			}
+		}
+
+		targetRepoID, err := client.ResolveRepositoryID(ctx, targetOwner, targetRepo)
+		if err != nil {
+			cmdutil.Fatalf("Failed to resolve target repository %s: %v", target, err)
+		}
+
+		newNumber, url, err := client.TransferIssue(ctx, details.ID, targetRepoID)
+		if err != nil {
+			cmdutil.Fatalf("Failed to transfer issue #%d: %v", issueNumber, err)
+		}
+		fmt.Printf("Transferred %s/%s#%d to %s#%d (%s)\n", owner, repo, issueNumber, target, newNumber, url)
+
+		if file != "" {
+			if err := updateFile(file, newNumber, target); err != nil {
+				cmdutil.Fatalf("Failed to update %s: %v", file, err)
+			}
+			fmt.Printf("Updated id in %s to %d and repo to %s\n", file, newNumber, target)
+		}
+	},
+}
+
+// updateFile points the front matter of a transferred issue's file at its new number and repository,
+// so later runs update the transferred issue instead of looking for it in the source repository.
+func updateFile(path string, number int64, target string) error {
+	if err := issuemanager.WriteIssueID(path, number, issuemanager.IDPositionLast); err != nil {
+		return err
+	}
+	return issuemanager.WriteFrontMatterValue(path, "repo", target, issuemanager.IDPositionLast)
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "Source GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "Source GitHub repository name")
+	Cmd.Flags().Int64VarP(&issueNumber, "issue", "i", 0, "Number of the issue to transfer")
+	Cmd.Flags().StringVar(&target, "to", "", "Target repository as owner/repo")
+	Cmd.Flags().StringVarP(&file, "file", "f", "", "Markdown file whose id and repo should be updated to the transferred issue")
+	Cmd.Flags().BoolVar(&dropType, "drop-type", false, "Transfer even if the target repository doesn't have the issue's type")
+	Cmd.MarkFlagRequired("issue")
+	Cmd.MarkFlagRequired("to")
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
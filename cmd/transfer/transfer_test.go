@@ -0,0 +1,45 @@
+package transfer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "repo replaced",
+			content: "---\ntitle: T\nid: 12\nrepo: octo/old\n---\nbody\n",
+			want:    "---\ntitle: T\nid: 3\nrepo: octo/new\n---\nbody\n",
+		},
+		{
+			name:    "repo inserted",
+			content: "---\ntitle: T\nid: 12\n---\nbody\n",
+			want:    "---\ntitle: T\nid: 3\nrepo: octo/new\n---\nbody\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "issue.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := updateFile(path, 3, "octo/new"); err != nil {
+				t.Fatalf("updateFile: %v", err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("file = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
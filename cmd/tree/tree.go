@@ -0,0 +1,193 @@
+package tree
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var folder string
+var remote bool
+var owner string
+var repo string
+var failOnEmpty bool
+var expandEmoji bool
+
+var Cmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the parent/child hierarchy of issue files",
+	Long:  "Show the parent/child hierarchy of issue files. With --remote, issues with an id also show GitHub's sub-issue progress, and sub-issues that differ between the files and GitHub are flagged.",
+	Run: func(cmd *cobra.Command, args []string) {
+		issues, err := issuemanager.ReadIssueFiles(folder)
+		if err != nil {
+			cmdutil.Fatalf("Error reading issue files: %v", err)
+		}
+		cmdutil.ExitIfNoIssueFiles(len(issues), folder, false, failOnEmpty)
+
+		p := printer{children: make(map[string][]issuemanager.Issue)}
+		titles := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			titles[normalize(issue.Title)] = true
+		}
+		var roots []issuemanager.Issue
+		for _, issue := range issues {
+			parent := normalize(issue.Parent)
+			if parent == "" || !titles[parent] {
+				// Parents that aren't local files can't be drawn; show the child at the top level
+				roots = append(roots, issue)
+				continue
+			}
+			p.children[parent] = append(p.children[parent], issue)
+		}
+
+		if remote {
+			p.ctx = cmd.Context()
+			p.client = authenticate(p.ctx)
+
+			// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+			inferredOwner, inferredRepo := git.InferOwnerRepo()
+			if owner == "" {
+				owner = inferredOwner
+			}
+			if repo == "" {
+				repo = inferredRepo
+			}
+			cmdutil.SetErrorTarget(owner, repo)
+			if owner == "" || repo == "" {
+				cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+			}
+		}
+
+		for _, issue := range roots {
+			p.print(issue, 0, make(map[string]bool))
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
+	Cmd.Flags().BoolVar(&remote, "remote", false, "Show GitHub's sub-issue progress for issues with an id and flag mismatches with the local hierarchy")
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name (used with --remote)")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name (used with --remote)")
+	Cmd.Flags().BoolVar(&expandEmoji, "expand-emoji", false, "Show GitHub emoji shortcodes (e.g. :rocket:) in titles as unicode")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+}
+
+// printer renders the local issue hierarchy, optionally annotated with GitHub's sub-issues.
+type printer struct {
+	children map[string][]issuemanager.Issue // normalized parent title -> local children
+	ctx      context.Context
+	client   *ghclient.Client // nil unless --remote
+}
+
+func (p printer) print(issue issuemanager.Issue, depth int, seen map[string]bool) {
+	indent := strings.Repeat("  ", depth)
+	key := normalize(issue.Title)
+	if seen[key] {
+		fmt.Printf("%s- %s [cycle]\n", indent, issue.Title)
+		return
+	}
+	seen[key] = true
+	defer delete(seen, key)
+
+	title := issue.Title
+	if expandEmoji {
+		title = issuemanager.ExpandEmoji(title)
+	}
+	line := fmt.Sprintf("%s- %s", indent, title)
+	if id := strings.TrimSpace(issue.Id); id != "" {
+		line += " (#" + id + ")"
+	}
+	children := p.children[key]
+
+	if p.client == nil {
+		fmt.Println(line)
+	} else {
+		p.printRemote(line, indent, issue, children)
+	}
+
+	for _, child := range children {
+		p.print(child, depth+1, seen)
+	}
+}
+
+// printRemote prints line with GitHub's sub-issue progress and reports sub-issues that exist only
+// locally or only on GitHub.
+func (p printer) printRemote(line, indent string, issue issuemanager.Issue, children []issuemanager.Issue) {
+	id := strings.TrimSpace(issue.Id)
+	if id == "" {
+		fmt.Println(line + "  [not created yet]")
+		return
+	}
+	number, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		fmt.Printf("%s  [invalid id %q]\n", line, id)
+		return
+	}
+
+	sub, err := p.client.GetSubIssues(p.ctx, owner, repo, number)
+	if err != nil {
+		logger.Debug("Failed to fetch sub-issues", "number", number, "error", err)
+		fmt.Printf("%s  [error: %v]\n", line, err)
+		return
+	}
+	if sub.Total > 0 {
+		line += fmt.Sprintf("  [%d/%d done, %d%%]", sub.Completed, sub.Total, sub.PercentCompleted)
+	}
+	fmt.Println(line)
+
+	remoteNumbers := make(map[int64]bool, len(sub.Issues))
+	for _, ref := range sub.Issues {
+		remoteNumbers[ref.Number] = true
+	}
+	localNumbers := make(map[int64]bool, len(children))
+	for _, child := range children {
+		childNumber, err := strconv.ParseInt(strings.TrimSpace(child.Id), 10, 64)
+		if err != nil {
+			continue
+		}
+		localNumbers[childNumber] = true
+		if !remoteNumbers[childNumber] {
+			fmt.Printf("%s    [MISMATCH: #%d %q is a local child but not a sub-issue on GitHub]\n", indent, childNumber, child.Title)
+		}
+	}
+	for _, ref := range sub.Issues {
+		if !localNumbers[ref.Number] {
+			fmt.Printf("%s    [MISMATCH: #%d %q is a sub-issue on GitHub but not a local child]\n", indent, ref.Number, ref.Title)
+		}
+	}
+}
+
+func normalize(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
@@ -0,0 +1,214 @@
+package update
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/git"
+	ghclient "github-issue-manager/pkg/github"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+var owner string
+var repo string
+var csvFile string
+var dryRun bool
+
+// Row is a single parsed CSV row describing the changes for one issue.
+type Row struct {
+	Line   int
+	Number int64
+	Update ghclient.IssueFieldUpdate
+}
+
+var Cmd = &cobra.Command{
+	Use:   "update",
+	Short: "Bulk update issues from a CSV file",
+	Long: `Bulk update existing issues from a CSV file keyed by issue number.
+
+The header row must contain a "number" (or "id") column and any of the columns
+"labels", "milestone", "state" and "assignees". Labels and assignees are
+comma-separated within their cell. Empty cells leave the field unchanged. A row
+with a label, milestone or assignee that doesn't exist fails without changing the
+issue.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rows, err := readRows(csvFile)
+		if err != nil {
+			cmdutil.Fatalf("Failed to read CSV file: %v", err)
+		}
+
+		ctx := cmd.Context()
+		var client *ghclient.Client
+		if !dryRun {
+			client = authenticate(ctx)
+		}
+
+		// Infer owner and repo from GitHub Actions or .git/config if not provided via flags
+		inferredOwner, inferredRepo := git.InferOwnerRepo()
+		if owner == "" {
+			owner = inferredOwner
+		}
+		if repo == "" {
+			repo = inferredRepo
+		}
+		cmdutil.SetErrorTarget(owner, repo)
+		if owner == "" || repo == "" {
+			cmdutil.Fatalf("Owner and repository name must be specified either via flags or inferred from .git/config")
+		}
+
+		failed := 0
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LINE\tISSUE\tRESULT\tCHANGES")
+		for _, row := range rows {
+			changes := describe(row.Update)
+			if dryRun {
+				fmt.Fprintf(w, "%d\t#%d\twould update\t%s\n", row.Line, row.Number, changes)
+				continue
+			}
+
+			if err := client.UpdateIssueFields(ctx, owner, repo, row.Number, row.Update); err != nil {
+				failed++
+				logger.Debug("Failed to update issue", "number", row.Number, "error", err)
+				fmt.Fprintf(w, "%d\t#%d\terror: %v\t%s\n", row.Line, row.Number, err, changes)
+				continue
+			}
+			fmt.Fprintf(w, "%d\t#%d\tupdated\t%s\n", row.Line, row.Number, changes)
+		}
+		w.Flush()
+
+		if failed > 0 {
+			cmdutil.Fatalf("%d of %d rows failed", failed, len(rows))
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&owner, "owner", "o", "", "GitHub owner name")
+	Cmd.Flags().StringVarP(&repo, "repo", "r", "", "GitHub repository name")
+	Cmd.Flags().StringVarP(&csvFile, "file", "f", "", "CSV file with one row per issue to update")
+	Cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Show the changes without applying them")
+	Cmd.MarkFlagRequired("file")
+}
+
+// readRows parses the CSV file into per-issue updates.
+func readRows(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	numberCol, ok := columns["number"]
+	if !ok {
+		if numberCol, ok = columns["id"]; !ok {
+			return nil, fmt.Errorf("header must contain a number or id column")
+		}
+	}
+
+	cell := func(record []string, name string) (string, bool) {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return "", false
+		}
+		value := strings.TrimSpace(record[i])
+		return value, value != ""
+	}
+
+	var rows []Row
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		number, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(record[numberCol]), "#"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid issue number %q", line, record[numberCol])
+		}
+
+		row := Row{Line: line, Number: number}
+		if value, ok := cell(record, "labels"); ok {
+			row.Update.Labels = issuemanager.SplitLabels(value)
+		}
+		if value, ok := cell(record, "milestone"); ok {
+			row.Update.Milestone = &value
+		}
+		if value, ok := cell(record, "state"); ok {
+			row.Update.State = &value
+		}
+		if value, ok := cell(record, "assignees"); ok {
+			row.Update.Assignees = issuemanager.SplitLabels(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// describe renders the fields an update will change.
+func describe(update ghclient.IssueFieldUpdate) string {
+	var parts []string
+	if update.Labels != nil {
+		parts = append(parts, "labels="+strings.Join(update.Labels, ","))
+	}
+	if update.Milestone != nil {
+		parts = append(parts, "milestone="+*update.Milestone)
+	}
+	if update.State != nil {
+		parts = append(parts, "state="+*update.State)
+	}
+	if update.Assignees != nil {
+		parts = append(parts, "assignees="+strings.Join(update.Assignees, ","))
+	}
+	if len(parts) == 0 {
+		return "(no changes)"
+	}
+	return strings.Join(parts, " ")
+}
+
+func authenticate(ctx context.Context) *ghclient.Client {
+	token := ghclient.TokenFromEnv()
+	if token == "" {
+		// Try to read token from hosts file
+		hostsToken, err := ghclient.ReadTokenFromHostsFile()
+		if err != nil {
+			logger.Error("Failed to read token from hosts file", "error", err)
+			cmdutil.Fatalf("Failed to read token from hosts file: %v", err)
+		}
+		if hostsToken != "" {
+			token = hostsToken
+		} else {
+			logger.Error("GITHUB_TOKEN environment variable and hosts file token are both not set")
+			cmdutil.Fatalf("GITHUB_TOKEN environment variable and hosts file token are both not set")
+		}
+	}
+
+	logger.Debug("Creating GitHub client with token")
+	return ghclient.NewClient(ctx, token)
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.csv")
+	content := "Number,labels,milestone,state,assignees\n" +
+		"#12,\"bug, ui\",,closed,\n" +
+		"13,,v1.0,,\"octocat,hubot\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := readRows(path)
+	if err != nil {
+		t.Fatalf("readRows: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	first, second := rows[0], rows[1]
+	if first.Line != 2 || first.Number != 12 || !reflect.DeepEqual(first.Update.Labels, []string{"bug", "ui"}) {
+		t.Errorf("row 1 = %+v, want line 2, #12 with labels [bug ui]", first)
+	}
+	if first.Update.State == nil || *first.Update.State != "closed" || first.Update.Milestone != nil || first.Update.Assignees != nil {
+		t.Errorf("row 1 update = %+v, want only state=closed besides the labels", first.Update)
+	}
+	if second.Number != 13 || second.Update.Labels != nil || second.Update.Milestone == nil || *second.Update.Milestone != "v1.0" {
+		t.Errorf("row 2 = %+v, want #13 with milestone v1.0 and labels left unchanged", second)
+	}
+	if !reflect.DeepEqual(second.Update.Assignees, []string{"octocat", "hubot"}) {
+		t.Errorf("row 2 assignees = %v, want [octocat hubot]", second.Update.Assignees)
+	}
+}
+
+func TestReadRowsErrors(t *testing.T) {
+	tests := map[string]string{
+		"no number column":     "labels\nbug\n",
+		"invalid issue number": "number,labels\nabc,bug\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "updates.csv")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := readRows(path); err == nil {
+				t.Error("readRows: want an error")
+			}
+		})
+	}
+}
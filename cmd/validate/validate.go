@@ -0,0 +1,177 @@
+package validate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github-issue-manager/cmd/cmdutil"
+	"github-issue-manager/pkg/config"
+	issuemanager "github-issue-manager/pkg/issuemanager"
+)
+
+var folder string
+var strictKeys bool
+var failOnEmpty bool
+var parallelFiles int
+var recursive bool
+var titleFromH1 bool
+var schemaFile string
+var allowDuplicateTitles bool
+var bodyTrim string
+
+// Problem is a single validation finding for an issue file.
+type Problem struct {
+	File    string
+	Line    int // 1-based line in File, or 0 when the problem isn't tied to a line
+	Message string
+	IsError bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate issue files without touching GitHub",
+	Long:  "Validate issue markdown files, reporting missing and duplicate titles, fields required for the issue's type by the config file's required_fields policy, and unknown front matter keys (prefix intentional custom keys with x- or _).",
+	Run: func(cmd *cobra.Command, args []string) {
+		trim, err := issuemanager.ParseBodyTrim(bodyTrim)
+		if err != nil {
+			cmdutil.Fatalf("Invalid --body-trim: %v", err)
+		}
+		issues, err := issuemanager.ReadIssueFilesWithOptions(folder, issuemanager.ReadOptions{Workers: parallelFiles, Recursive: recursive, BodyTrim: trim})
+		if err != nil {
+			cmdutil.Fatalf("Error reading issue files: %v", err)
+		}
+
+		cmdutil.ExitIfNoIssueFiles(len(issues), folder, recursive, failOnEmpty)
+
+		if titleFromH1 {
+			// Files with neither a title: nor a heading are reported as missing titles below
+			_ = issuemanager.TitleFromH1(issues)
+		}
+
+		problems := Check(issues, strictKeys, config.Current().RequiredFields)
+		if schemaFile != "" {
+			schema, err := issuemanager.LoadSchema(schemaFile)
+			if err != nil {
+				cmdutil.Fatalf("Error loading schema: %v", err)
+			}
+			schemaProblems, err := CheckSchema(issues, schema, folder)
+			if err != nil {
+				cmdutil.Fatalf("Error checking schema: %v", err)
+			}
+			problems = append(problems, schemaProblems...)
+		}
+		problems = append(problems, CheckDuplicateTitles(issues, !allowDuplicateTitles)...)
+
+		errors, warnings := 0, 0
+		for _, problem := range problems {
+			level := "warning"
+			if problem.IsError {
+				level = "error"
+				errors++
+			} else {
+				warnings++
+			}
+			location := problem.File
+			if problem.Line > 0 {
+				location = fmt.Sprintf("%s:%d", problem.File, problem.Line)
+			}
+			fmt.Printf("%s: %s: %s\n", location, level, problem.Message)
+		}
+
+		fmt.Printf("%d file(s) checked, %d error(s), %d warning(s)\n", len(issues), errors, warnings)
+		if errors > 0 {
+			cmdutil.Failf("%d error(s) in issue files", errors)
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&folder, "folder", "f", "issues", "Folder containing issue files")
+	Cmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit non-zero when no issue files are found")
+	Cmd.Flags().BoolVar(&titleFromH1, "title-from-h1", false, "Use the first # heading of the body (removed from the body) as the title of files without title:")
+	Cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Also read issue files in subdirectories of the folder (e.g. one per type)")
+	Cmd.Flags().IntVar(&parallelFiles, "parallel-files", 1, "Number of issue files parsed concurrently")
+	Cmd.Flags().StringVar(&bodyTrim, "body-trim", "edges", "Whitespace trimmed from bodies: none, edges (leading/trailing blank lines) or all (also every line's indentation)")
+	Cmd.Flags().StringVar(&schemaFile, "schema", "", "YAML schema of allowed front matter keys, kinds and values to check every file against")
+	Cmd.Flags().BoolVar(&allowDuplicateTitles, "allow-duplicate-titles", false, "Report files sharing a title (ignoring case) as warnings instead of errors")
+	Cmd.Flags().BoolVar(&strictKeys, "strict-keys", false, "Treat unknown front matter keys as errors instead of warnings")
+}
+
+// Check runs every validation rule against issues and returns the findings in file order.
+// requiredFields is the per-type required-field policy from the config file.
+func Check(issues []issuemanager.Issue, strictKeys bool, requiredFields map[string][]string) []Problem {
+	var problems []Problem
+	for _, issue := range issues {
+		file := filepath.Join(issue.Path, issue.FileName)
+
+		if strings.TrimSpace(issue.Title) == "" {
+			problems = append(problems, Problem{File: file, Message: "missing title", IsError: true})
+		}
+
+		for _, field := range issuemanager.MissingRequiredFields(issue, requiredFields) {
+			problems = append(problems, Problem{
+				File:    file,
+				Message: fmt.Sprintf("missing field %q required for type %q", field, issue.Type),
+				IsError: true,
+			})
+		}
+
+		for _, key := range issuemanager.UnknownFrontMatterKeys(issue.FrontMatter) {
+			problems = append(problems, Problem{
+				File:    file,
+				Message: fmt.Sprintf("unknown front matter key %q (prefix custom keys with x- or _)", key),
+				IsError: strictKeys,
+			})
+		}
+	}
+	return problems
+}
+
+// CheckSchema checks the front matter of every issue file read from root, merged with its folder
+// defaults, against schema. Issues without a backing file are skipped.
+func CheckSchema(issues []issuemanager.Issue, schema *issuemanager.Schema, root string) ([]Problem, error) {
+	var problems []Problem
+	for _, issue := range issues {
+		if issue.FileName == "" {
+			continue
+		}
+		file := filepath.Join(issue.Path, issue.FileName)
+		defaults, err := issuemanager.ReadDefaultsChain(root, issue.Path)
+		if err != nil {
+			return nil, err
+		}
+		violations, err := schema.Check(file, defaults)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range violations {
+			problems = append(problems, Problem{File: file, Line: violation.Line, Message: violation.Message, IsError: true})
+		}
+	}
+	return problems, nil
+}
+
+// CheckDuplicateTitles reports every file whose title (ignoring case and surrounding whitespace) is
+// shared with another file, naming the other files. Duplicates make parent resolution ambiguous.
+func CheckDuplicateTitles(issues []issuemanager.Issue, isError bool) []Problem {
+	var problems []Problem
+	for _, group := range issuemanager.DuplicateTitles(issues) {
+		for i, issue := range group {
+			var others []string
+			for j, other := range group {
+				if j != i {
+					others = append(others, filepath.Join(other.Path, other.FileName))
+				}
+			}
+			problems = append(problems, Problem{
+				File:    filepath.Join(issue.Path, issue.FileName),
+				Message: fmt.Sprintf("duplicate title %q (also in %s)", strings.TrimSpace(issue.Title), strings.Join(others, ", ")),
+				IsError: isError,
+			})
+		}
+	}
+	return problems
+}
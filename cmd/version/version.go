@@ -0,0 +1,52 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// Build information, injected at build time with
+// -ldflags "-X github-issue-manager/cmd/version.Version=... -X ...Commit=... -X ...Date=...".
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+var Cmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version, commit and build information",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(String())
+	},
+}
+
+// String describes the build: version, commit, build date, Go version and platform.
+func String() string {
+	commit, date := Commit, Date
+	if info, ok := debug.ReadBuildInfo(); ok {
+		// Fall back to the VCS stamp Go embeds in binaries built from a checkout
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if commit == "" {
+					commit = setting.Value
+				}
+			case "vcs.time":
+				if date == "" {
+					date = setting.Value
+				}
+			}
+		}
+	}
+	if commit == "" {
+		commit = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s/%s)", Version, commit, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -3,8 +3,10 @@ module github-issue-manager
 go 1.24.6
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/machinebox/graphql v0.2.2
 	github.com/spf13/cobra v1.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -12,4 +14,5 @@ require (
 	github.com/matryer/is v1.4.1 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 )
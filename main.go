@@ -1,37 +1,119 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github-issue-manager/cmd/cmdutil"
 	"github-issue-manager/cmd/create"
+	"github-issue-manager/cmd/discussion"
+	"github-issue-manager/cmd/doctor"
 	"github-issue-manager/cmd/examples"
+	"github-issue-manager/cmd/hierarchy"
 	"github-issue-manager/cmd/info"
 	"github-issue-manager/cmd/list"
+	"github-issue-manager/cmd/parse"
+	"github-issue-manager/cmd/projects"
+	"github-issue-manager/cmd/refs"
+	"github-issue-manager/cmd/search"
+	"github-issue-manager/cmd/transfer"
+	"github-issue-manager/cmd/tree"
+	"github-issue-manager/cmd/update"
+	"github-issue-manager/cmd/validate"
+	"github-issue-manager/cmd/version"
+	"github-issue-manager/pkg/config"
+	ghclient "github-issue-manager/pkg/github"
 	"github-issue-manager/pkg/logger"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	logLevel   string
-	jsonFormat bool
+	logLevel        string
+	jsonFormat      bool
+	configPath      string
+	errorJSON       bool
+	endpoint        string
+	insecure        bool
+	caFile          string
+	maxRetriesTotal int
+	maxCallRetries  int
+	timeout         time.Duration
+	cancelTimeout   context.CancelFunc
 )
 
 func main() {
 	var rootCmd = &cobra.Command{
-		Use:   "github-issue-manager",
-		Short: "A CLI tool to create GitHub issues",
+		Use:     "github-issue-manager",
+		Short:   "A CLI tool to create GitHub issues",
+		Version: version.String(),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Initialize logger with flags
 			logger.Init(logger.LogLevel(logLevel), jsonFormat)
+			cmdutil.ErrorJSON = errorJSON || jsonFormat
+			cmdutil.SetErrorCommand(cmd.CommandPath())
+			ghclient.GraphQLEndpoint = endpoint
+			ghclient.MaxRetries = maxCallRetries
+			ghclient.MaxRetriesTotal = maxRetriesTotal
+			if timeout > 0 {
+				var ctx context.Context
+				ctx, cancelTimeout = context.WithTimeout(cmd.Context(), timeout)
+				cmd.SetContext(ctx)
+			}
+			if err := ghclient.ConfigureTLS(insecure, caFile); err != nil {
+				cmdutil.Fatalf("Error configuring TLS: %v", err)
+			}
+
+			if err := config.Init(configPath); err != nil {
+				cmdutil.Fatalf("Error loading config: %v", err)
+			}
 		},
 	}
 
 	// Add persistent flags for logging
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
+
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Set log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().BoolVar(&jsonFormat, "log-json", false, "Output logs in JSON format")
+	rootCmd.PersistentFlags().BoolVar(&errorJSON, "error-json", false, "Print fatal errors as a JSON object on stderr (implied by --log-json)")
+	rootCmd.PersistentFlags().StringVar(&endpoint, "graphql-endpoint", ghclient.DefaultGraphQLEndpoint, "Full GraphQL endpoint URL (e.g. a proxy or mock server)")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure-skip-verify", false, "Disable TLS certificate verification (unsafe; prefer --ca-file)")
+	rootCmd.PersistentFlags().StringVar(&caFile, "ca-file", "", "PEM file with additional CA certificates to trust (e.g. for self-signed GitHub Enterprise)")
+	rootCmd.PersistentFlags().IntVar(&maxCallRetries, "max-retries", ghclient.MaxRetries, "Retries of a GitHub call hit by a rate limit or 5xx error, waiting for Retry-After or backing off exponentially")
+	rootCmd.PersistentFlags().IntVar(&maxRetriesTotal, "max-retries-total", 0, "Retries allowed across the whole run before failing fast (0 = unlimited)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort the command after this long (e.g. 10m); 0 means no limit")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Configuration file (default "+config.DefaultPath+" if present)")
 
 	rootCmd.AddCommand(list.Cmd)
 	rootCmd.AddCommand(create.Cmd)
 	rootCmd.AddCommand(examples.Cmd)
 	rootCmd.AddCommand(info.Cmd)
-	rootCmd.Execute()
+	rootCmd.AddCommand(search.Cmd)
+	rootCmd.AddCommand(transfer.Cmd)
+	rootCmd.AddCommand(update.Cmd)
+	rootCmd.AddCommand(validate.Cmd)
+	rootCmd.AddCommand(doctor.Cmd)
+	rootCmd.AddCommand(projects.Cmd)
+	rootCmd.AddCommand(discussion.Cmd)
+	rootCmd.AddCommand(tree.Cmd)
+	rootCmd.AddCommand(refs.Cmd)
+	rootCmd.AddCommand(hierarchy.Cmd)
+	rootCmd.AddCommand(parse.Cmd)
+	rootCmd.AddCommand(version.Cmd)
+
+	// Ctrl-C cancels the command's context so in-flight GitHub calls stop and create can report
+	// what it finished; a second Ctrl-C exits immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	rootCmd.ExecuteContext(ctx)
+	if cancelTimeout != nil {
+		cancelTimeout()
+	}
 }
@@ -0,0 +1,86 @@
+// Package config loads the optional project configuration file shared by all commands.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the configuration file read from the working directory when --config is not given.
+const DefaultPath = ".gim.yaml"
+
+// Config is the project configuration.
+type Config struct {
+	// TypeAliases maps issue type names used in markdown files to the repository's actual type names
+	// (e.g. Feature: Enhancement). Names are matched case-insensitively.
+	TypeAliases map[string]string `yaml:"type_aliases"`
+
+	// RequiredFields lists, per issue type, the front matter keys every file of that type must set
+	// (e.g. Bug: [x-severity, x-repro-steps]). Types are matched case-insensitively.
+	RequiredFields map[string][]string `yaml:"required_fields"`
+
+	// StatusAliases maps status values used in markdown files to the option names of a project's
+	// Status field (e.g. todo: Backlog). Values are matched case-insensitively.
+	StatusAliases map[string]string `yaml:"status_aliases"`
+
+	// TypeFromLabels derives the issue type of files without a type: from one of their labels
+	// (e.g. bug: Bug), easing migration from label-encoded types. Labels are matched case-insensitively.
+	TypeFromLabels map[string]string `yaml:"type_from_labels"`
+}
+
+var current = &Config{}
+
+// Current returns the configuration loaded by Init, or an empty configuration.
+func Current() *Config {
+	return current
+}
+
+// Init loads the configuration file at path and makes it current. An empty path loads DefaultPath
+// if it exists; an explicitly given path must exist.
+func Init(path string) error {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	current = cfg
+	return nil
+}
+
+// Load reads and parses a configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ParseAliases parses "From=To" pairs (as given on the command line) into an alias map.
+func ParseAliases(pairs []string) (map[string]string, error) {
+	aliases := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			return nil, fmt.Errorf("invalid alias %q (expected From=To)", pair)
+		}
+		aliases[strings.TrimSpace(from)] = strings.TrimSpace(to)
+	}
+	return aliases, nil
+}
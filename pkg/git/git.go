@@ -45,6 +45,24 @@ func ParseGitRemoteURL(url string) (owner, repo string, err error) {
 	return "", "", fmt.Errorf("unsupported URL format: %s", url)
 }
 
+// InActions reports whether the process is running inside a GitHub Actions workflow.
+func InActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// InferOwnerRepo infers the GitHub owner and repository name, preferring GITHUB_REPOSITORY
+// when running in GitHub Actions and falling back to the local .git configuration.
+func InferOwnerRepo() (inferredOwner, inferredRepo string) {
+	if InActions() {
+		if owner, repo, ok := strings.Cut(os.Getenv("GITHUB_REPOSITORY"), "/"); ok && owner != "" && repo != "" {
+			logger.Debug("Inferred owner/repo from GITHUB_REPOSITORY", "owner", owner, "repo", repo)
+			return owner, repo
+		}
+		logger.Debug("Running in GitHub Actions but GITHUB_REPOSITORY is not set or malformed")
+	}
+	return InferOwnerRepoFromGit()
+}
+
 // InferOwnerRepoFromGit attempts to infer the GitHub owner and repository name
 // from the local .git configuration.
 func InferOwnerRepoFromGit() (inferredOwner, inferredRepo string) {
@@ -0,0 +1,301 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+// resolveCache memoizes read-only lookups (repository, label, issue type and project IDs).
+// A nil cache is valid and simply caches nothing. It lives as long as the client rather than a
+// single CreateIssues call so that prefetching and the disk cache can seed it before a batch.
+type resolveCache struct {
+	mu         sync.Mutex
+	repoIDs    map[string]string                  // owner/repo -> repository node ID
+	labelIDs   map[string]map[string]string       // owner/repo -> normalized label name -> label node ID
+	typeIDs    map[string]map[string]string       // owner/repo -> normalized type name -> issue type node ID
+	projectIDs map[string]string                  // owner/normalized project title -> project node ID
+	fields     map[string]*projectFieldDefinition // project node ID/normalized field name -> field
+	issueIDs   map[string]string                  // owner/repo/normalized title -> node ID of issues created or updated this run
+
+	diskSavedAt map[string]time.Time // persisted cache key -> when the entry loaded from disk was fetched
+}
+
+func newResolveCache() *resolveCache {
+	return &resolveCache{
+		repoIDs:    make(map[string]string),
+		labelIDs:   make(map[string]map[string]string),
+		typeIDs:    make(map[string]map[string]string),
+		projectIDs: make(map[string]string),
+		fields:     make(map[string]*projectFieldDefinition),
+		issueIDs:   make(map[string]string),
+	}
+}
+
+// normalizeName normalizes a label, type or project name for case-insensitive matching.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func repoKey(owner, repo string) string {
+	return strings.ToLower(owner) + "/" + strings.ToLower(repo)
+}
+
+func (rc *resolveCache) repoID(owner, repo string) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	id, ok := rc.repoIDs[repoKey(owner, repo)]
+	return id, ok
+}
+
+func (rc *resolveCache) setRepoID(owner, repo, id string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.repoIDs[repoKey(owner, repo)] = id
+}
+
+func (rc *resolveCache) labels(owner, repo string) (map[string]string, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	labels, ok := rc.labelIDs[repoKey(owner, repo)]
+	if !ok {
+		return nil, false
+	}
+	// Return a copy so callers can't race with addLabel
+	out := make(map[string]string, len(labels))
+	for name, id := range labels {
+		out[name] = id
+	}
+	return out, true
+}
+
+func (rc *resolveCache) setLabels(owner, repo string, labels map[string]string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.labelIDs[repoKey(owner, repo)] = labels
+}
+
+func (rc *resolveCache) addLabel(owner, repo, name, id string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	key := repoKey(owner, repo)
+	if rc.labelIDs[key] == nil {
+		// Labels were never fetched; leave the cache empty so a later lookup fetches the full set
+		return
+	}
+	rc.labelIDs[key][normalizeName(name)] = id
+}
+
+func (rc *resolveCache) issueTypes(owner, repo string) (map[string]string, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	types, ok := rc.typeIDs[repoKey(owner, repo)]
+	return types, ok
+}
+
+func (rc *resolveCache) setIssueTypes(owner, repo string, types map[string]string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.typeIDs[repoKey(owner, repo)] = types
+}
+
+// dropDiskEntry forgets owner/repo's labels or issue types (prefix diskCacheLabels or
+// diskCacheTypes) if they were loaded from the disk cache and reports whether it did. The entry
+// is refetched from GitHub on the next lookup, so a stale entry is refreshed at most once.
+func (rc *resolveCache) dropDiskEntry(prefix, owner, repo string) bool {
+	if rc == nil {
+		return false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	key := repoKey(owner, repo)
+	if _, ok := rc.diskSavedAt[prefix+key]; !ok {
+		return false
+	}
+	delete(rc.diskSavedAt, prefix+key)
+	switch prefix {
+	case diskCacheLabels:
+		delete(rc.labelIDs, key)
+	case diskCacheTypes:
+		delete(rc.typeIDs, key)
+	}
+	return true
+}
+
+// missingName reports whether any of names is absent from ids, which is keyed by normalized name.
+func missingName(ids map[string]string, names []string) bool {
+	for _, name := range names {
+		if _, ok := ids[normalizeName(name)]; !ok && strings.TrimSpace(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *resolveCache) projectID(owner, title string) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	id, ok := rc.projectIDs[strings.ToLower(owner)+"/"+normalizeName(title)]
+	return id, ok
+}
+
+func (rc *resolveCache) setProjectID(owner, title, id string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.projectIDs[strings.ToLower(owner)+"/"+normalizeName(title)] = id
+}
+
+func (rc *resolveCache) projectField(projectID, name string) (*projectFieldDefinition, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	field, ok := rc.fields[projectID+"/"+normalizeName(name)]
+	return field, ok
+}
+
+func (rc *resolveCache) setProjectField(projectID, name string, field *projectFieldDefinition) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.fields[projectID+"/"+normalizeName(name)] = field
+}
+
+func (rc *resolveCache) issueID(owner, repo, title string) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	id, ok := rc.issueIDs[repoKey(owner, repo)+"/"+normalizeName(title)]
+	return id, ok
+}
+
+func (rc *resolveCache) setIssueID(owner, repo, title, id string) {
+	if rc == nil || id == "" {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.issueIDs[repoKey(owner, repo)+"/"+normalizeName(title)] = id
+}
+
+// PrefetchResolutions warms the resolution cache for every repository, label set, issue type set
+// and project referenced by issues, running up to concurrency read-only lookups in parallel.
+// Issues that override their target repository are prefetched against that repository.
+// Failures are only logged; the create loop reports them when it hits the same lookup.
+func (c *Client) PrefetchResolutions(ctx context.Context, owner, repo string, issues []issuemanager.Issue, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var tasks []func() error
+	for _, group := range groupIssuesByTarget(issues, owner, repo) {
+		tasks = append(tasks, prefetchTasks(ctx, c, group)...)
+	}
+	if len(tasks) == 0 {
+		// No issues; still resolve the default repository so later lookups hit the cache
+		tasks = prefetchTasks(ctx, c, issueGroup{Owner: owner, Repo: repo})
+	}
+
+	logger.Debug("Prefetching resolutions", "lookups", len(tasks), "concurrency", concurrency)
+
+	work := make(chan func() error)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range work {
+				if err := task(); err != nil {
+					logger.Debug("Prefetch lookup failed", "error", err)
+				}
+			}
+		}()
+	}
+	for _, task := range tasks {
+		work <- task
+	}
+	close(work)
+	wg.Wait()
+}
+
+// prefetchTasks returns the lookups needed to process a group of issues sharing a target repository.
+func prefetchTasks(ctx context.Context, c *Client, group issueGroup) []func() error {
+	owner, repo := group.Owner, group.Repo
+
+	var tasks []func() error
+	tasks = append(tasks, func() error {
+		_, err := c.ResolveRepositoryID(ctx, owner, repo)
+		return err
+	})
+
+	needLabels, needTypes := false, false
+	projects := make(map[string]string)
+	for _, issue := range group.Issues {
+		if len(issue.Labels) > 0 {
+			needLabels = true
+		}
+		if strings.TrimSpace(issue.Type) != "" {
+			needTypes = true
+		}
+		if strings.TrimSpace(issue.Project) != "" {
+			projects[normalizeName(issue.Project)] = issue.Project
+		}
+	}
+
+	if needLabels {
+		tasks = append(tasks, func() error {
+			_, err := c.repoLabels(ctx, owner, repo)
+			return err
+		})
+	}
+	if needTypes {
+		tasks = append(tasks, func() error {
+			_, err := c.repoIssueTypes(ctx, owner, repo)
+			return err
+		})
+	}
+	for _, project := range projects {
+		project := project
+		tasks = append(tasks, func() error {
+			_, err := c.ResolveProjectID(ctx, owner, project)
+			return err
+		})
+	}
+	return tasks
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+func TestCreateIssuesResolvesRepositoryOnce(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.reply("labels(first: $first", obj{"repository": obj{"labels": obj{
+		"pageInfo": obj{"hasNextPage": false},
+		"nodes":    []obj{{"id": "L_bug", "name": "bug"}},
+	}}})
+	f.reply("issueTypes(first", obj{"repository": obj{"issueTypes": obj{
+		"nodes": []obj{{"id": "T_task", "name": "Task"}},
+	}}})
+	f.reply("repository(owner", obj{"repository": obj{"id": "R_1"}})
+	replyCreateIssue(f)
+
+	issues := []issuemanager.Issue{
+		{Title: "One", Type: "Task", Labels: []string{"bug"}},
+		{Title: "Two", Type: "Task", Labels: []string{"bug"}},
+		{Title: "Three", Labels: []string{"bug"}},
+	}
+	for _, result := range c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{}) {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Issue.Title, result.Err)
+		}
+	}
+
+	for query, want := range map[string]int{
+		"repository(owner: $owner, name: $name) { id }": 1,
+		"labels(first: $first":                          1,
+		"issueTypes(first":                              1,
+		"createIssue":                                   3,
+	} {
+		if got := len(f.calls(query)); got != want {
+			t.Errorf("%q sent %d times, want %d", query, got, want)
+		}
+	}
+}
+
+func TestCreateIssuesResolvesEachTargetRepositoryOnce(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("labels(first: $first", func(r fakeRequest) interface{} {
+		return obj{"repository": obj{"labels": obj{
+			"pageInfo": obj{"hasNextPage": false},
+			"nodes":    []obj{{"id": "L_bug_" + r.Variables["name"].(string), "name": "bug"}},
+		}}}
+	})
+	f.on("repository(owner", func(r fakeRequest) interface{} {
+		return obj{"repository": obj{"id": "R_" + r.Variables["name"].(string)}}
+	})
+	replyCreateIssue(f)
+
+	issues := []issuemanager.Issue{
+		{Title: "One", Labels: []string{"bug"}},
+		{Title: "Two", Repo: "other", Labels: []string{"bug"}},
+		{Title: "Three", Labels: []string{"bug"}},
+		{Title: "Four", Repo: "other", Labels: []string{"bug"}},
+	}
+	for _, result := range c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{}) {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Issue.Title, result.Err)
+		}
+	}
+
+	for _, name := range []string{"hello", "other"} {
+		var repoLookups, labelLookups int
+		for _, call := range f.calls("repository(owner: $owner, name: $name) { id }") {
+			if call.Variables["name"] == name {
+				repoLookups++
+			}
+		}
+		for _, call := range f.calls("labels(first: $first") {
+			if call.Variables["name"] == name {
+				labelLookups++
+			}
+		}
+		if repoLookups != 1 || labelLookups != 1 {
+			t.Errorf("%s: repository id resolved %d times and labels %d times, want 1 each", name, repoLookups, labelLookups)
+		}
+	}
+
+	var targets []interface{}
+	for _, call := range f.calls("createIssue") {
+		targets = append(targets, call.input()["repositoryId"])
+	}
+	if want := []interface{}{"R_hello", "R_hello", "R_other", "R_other"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("createIssue repositoryIds = %v, want the issues grouped by repository %v", targets, want)
+	}
+}
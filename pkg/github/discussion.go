@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/machinebox/graphql"
+)
+
+// Discussion is a GitHub discussion.
+type Discussion struct {
+	ID     string `json:"id"`
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+}
+
+// GetDiscussion fetches a discussion by number.
+func (c *Client) GetDiscussion(ctx context.Context, owner, repo string, number int64) (*Discussion, error) {
+	req := graphql.NewRequest(`
+		query($owner: String!, $name: String!, $number: Int!) {
+			repository(owner: $owner, name: $name) {
+				discussion(number: $number) {
+					id
+					number
+					title
+					body
+					url
+				}
+			}
+		}
+	`)
+	req.Var("owner", owner)
+	req.Var("name", repo)
+	req.Var("number", int(number))
+
+	var out struct {
+		Repository struct {
+			Discussion *Discussion `json:"discussion"`
+		} `json:"repository"`
+	}
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("discussion query failed: %w", err)
+	}
+	if out.Repository.Discussion == nil {
+		return nil, fmt.Errorf("discussion #%d not found in %s/%s", number, owner, repo)
+	}
+	return out.Repository.Discussion, nil
+}
+
+// AddDiscussionComment posts a comment on a discussion and returns the comment URL.
+func (c *Client) AddDiscussionComment(ctx context.Context, discussionID, body string) (string, error) {
+	req := graphql.NewRequest(`
+		mutation($input: AddDiscussionCommentInput!) {
+			addDiscussionComment(input: $input) {
+				comment { id url }
+			}
+		}
+	`)
+	req.Var("input", map[string]interface{}{
+		"discussionId": discussionID,
+		"body":         body,
+	})
+
+	var resp struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			} `json:"comment"`
+		} `json:"addDiscussionComment"`
+	}
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return "", fmt.Errorf("addDiscussionComment GraphQL failed: %w", err)
+	}
+	return resp.AddDiscussionComment.Comment.URL, nil
+}
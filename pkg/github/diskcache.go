@@ -0,0 +1,131 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Keys of the persisted cache are the resolveCache keys prefixed by what they resolve.
+const (
+	diskCacheRepo    = "repo:"
+	diskCacheLabels  = "labels:"
+	diskCacheTypes   = "types:"
+	diskCacheProject = "project:"
+)
+
+// diskCacheEntry is one persisted lookup result with the time it was fetched from GitHub.
+type diskCacheEntry struct {
+	SavedAt time.Time         `json:"saved_at"`
+	IDs     map[string]string `json:"ids"`
+}
+
+// DefaultDiskCachePath returns the file under the user cache directory holding the resolution
+// cache persisted across runs.
+func DefaultDiskCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gim", "resolve-cache.json"), nil
+}
+
+// LoadDiskCache seeds the client's resolution cache with the repository, label, issue type and
+// project IDs persisted at path that are younger than ttl. A missing file is not an error.
+func (c *Client) LoadDiskCache(path string, ttl time.Duration) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries map[string]diskCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("parse cache file %s: %w", path, err)
+	}
+
+	rc := c.cache
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.diskSavedAt == nil {
+		rc.diskSavedAt = make(map[string]time.Time)
+	}
+	for key, entry := range entries {
+		if time.Since(entry.SavedAt) > ttl || entry.IDs == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(key, diskCacheRepo):
+			rc.repoIDs[strings.TrimPrefix(key, diskCacheRepo)] = entry.IDs["id"]
+		case strings.HasPrefix(key, diskCacheLabels):
+			rc.labelIDs[strings.TrimPrefix(key, diskCacheLabels)] = entry.IDs
+		case strings.HasPrefix(key, diskCacheTypes):
+			rc.typeIDs[strings.TrimPrefix(key, diskCacheTypes)] = entry.IDs
+		case strings.HasPrefix(key, diskCacheProject):
+			rc.projectIDs[strings.TrimPrefix(key, diskCacheProject)] = entry.IDs["id"]
+		default:
+			continue
+		}
+		rc.diskSavedAt[key] = entry.SavedAt
+	}
+	return nil
+}
+
+// SaveDiskCache persists the repository, label, issue type and project IDs resolved so far to path.
+// Entries loaded by LoadDiskCache keep their original fetch time so they still expire. They are
+// merged into the entries already in the file, so a run that only touched some repositories, or
+// skipped loading with --refresh-cache, keeps the other repositories' entries.
+func (c *Client) SaveDiskCache(path string) error {
+	entries := make(map[string]diskCacheEntry)
+	if data, err := os.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &entries) != nil {
+			// A corrupt file is replaced rather than failing the save
+			entries = make(map[string]diskCacheEntry)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	rc := c.cache
+	rc.mu.Lock()
+	now := time.Now()
+	add := func(key string, ids map[string]string) {
+		savedAt, ok := rc.diskSavedAt[key]
+		if !ok {
+			savedAt = now
+		}
+		entries[key] = diskCacheEntry{SavedAt: savedAt, IDs: ids}
+	}
+	for key, id := range rc.repoIDs {
+		add(diskCacheRepo+key, map[string]string{"id": id})
+	}
+	for key, labels := range rc.labelIDs {
+		add(diskCacheLabels+key, labels)
+	}
+	for key, types := range rc.typeIDs {
+		add(diskCacheTypes+key, types)
+	}
+	for key, id := range rc.projectIDs {
+		add(diskCacheProject+key, map[string]string{"id": id})
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	rc.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
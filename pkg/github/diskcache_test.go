@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeDiskCache(t *testing.T, path string, entries map[string]diskCacheEntry) {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiskCacheMissRefetchesOnce(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.reply("labels(first: $first", obj{"repository": obj{"labels": obj{
+		"pageInfo": obj{"hasNextPage": false},
+		"nodes":    []obj{{"id": "L_bug", "name": "bug"}, {"id": "L_new", "name": "new"}},
+	}}})
+
+	path := filepath.Join(t.TempDir(), "cache.json")
+	writeDiskCache(t, path, map[string]diskCacheEntry{
+		diskCacheLabels + "octo/hello": {SavedAt: time.Now(), IDs: map[string]string{"bug": "L_bug"}},
+	})
+	if err := c.LoadDiskCache(path, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if got, _ := c.resolveLabelIDs(ctx, "octo", "hello", []string{"bug"}, nil); len(got) != 1 || len(f.calls("labels(first: $first")) != 0 {
+		t.Fatalf("cached label: got %v after %d queries, want [L_bug] from the cache", got, len(f.calls("labels(first: $first")))
+	}
+	if got, _ := c.resolveLabelIDs(ctx, "octo", "hello", []string{"new"}, nil); len(got) != 1 || got[0] != "L_new" {
+		t.Errorf("label created since caching: got %v, want [L_new]", got)
+	}
+	// A label that is still missing after the refetch doesn't refetch again
+	if _, err := c.resolveLabelIDs(ctx, "octo", "hello", []string{"gone"}, nil); err == nil {
+		t.Error("missing label: want an error")
+	}
+	if got := len(f.calls("labels(first: $first")); got != 1 {
+		t.Errorf("labels query sent %d times, want 1", got)
+	}
+}
+
+func TestSaveDiskCacheMergesExistingEntries(t *testing.T) {
+	_, c := newFakeGitHub(t)
+	path := filepath.Join(t.TempDir(), "cache.json")
+	other := diskCacheEntry{SavedAt: time.Now().Add(-time.Minute).UTC(), IDs: map[string]string{"id": "R_other"}}
+	writeDiskCache(t, path, map[string]diskCacheEntry{
+		diskCacheRepo + "octo/other": other,
+		diskCacheRepo + "octo/hello": {SavedAt: other.SavedAt, IDs: map[string]string{"id": "R_stale"}},
+	})
+
+	// Like --refresh-cache: nothing is loaded and this run resolves octo/hello afresh
+	c.cache.setRepoID("octo", "hello", "R_1")
+	if err := c.SaveDiskCache(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries map[string]diskCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if got := entries[diskCacheRepo+"octo/hello"].IDs["id"]; got != "R_1" {
+		t.Errorf("octo/hello = %q, want R_1", got)
+	}
+	if got := entries[diskCacheRepo+"octo/other"]; got.IDs["id"] != "R_other" || !got.SavedAt.Equal(other.SavedAt) {
+		t.Errorf("octo/other = %+v, want %+v", got, other)
+	}
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+// ReferenceProblem is a reference in an issue file that doesn't resolve on GitHub.
+type ReferenceProblem struct {
+	Issue issuemanager.Issue
+	Kind  string // repository, type, label, assignee, parent, project or project field
+	Name  string
+	Err   error
+}
+
+// PlannedAction is a mutation a real create run would perform.
+type PlannedAction struct {
+	Issue       issuemanager.Issue
+	Description string
+}
+
+// DryRunReport separates references that failed to resolve from the mutations a real run would make.
+type DryRunReport struct {
+	Problems []ReferenceProblem
+	Actions  []PlannedAction
+}
+
+// DryRun resolves (read-only) every repository, type, label, assignee, parent, project and project
+// field referenced by issues and reports what doesn't exist, alongside the mutations a real run would
+// perform. plannedLabels are labels the run creates up front (e.g. from a labels file); they and,
+// with CreateMissingLabels, any other missing label are reported as label creations instead of errors.
+// opts.DefaultType types untyped issues as it does in CreateIssues, including falling back to plain
+// issues with a warning when the repository doesn't have it. With opts.CreateMissingParents, a parent
+// that doesn't exist is planned as a placeholder issue instead of reported.
+func (c *Client) DryRun(ctx context.Context, owner, repo string, issues []issuemanager.Issue, plannedLabels []string, opts CreateOptions) *DryRunReport {
+	report := &DryRunReport{}
+	sortedIssues, err := issuemanager.SortIssuesByDependency(issues)
+	if err != nil {
+		for _, issue := range issuemanager.FindDependencyCycle(issues) {
+			report.Problems = append(report.Problems, ReferenceProblem{Issue: issue, Kind: "parent", Name: issue.Parent, Err: err})
+		}
+		return report
+	}
+
+	planned := make(map[string]bool, len(plannedLabels))
+	for _, label := range plannedLabels {
+		planned[normalizeName(label)] = true
+	}
+
+	for _, group := range groupIssuesByTarget(sortedIssues, owner, repo) {
+		owner, repo := group.Owner, group.Repo
+		if _, err := c.ResolveRepositoryID(ctx, owner, repo); err != nil {
+			for _, issue := range group.Issues {
+				report.Problems = append(report.Problems, ReferenceProblem{Issue: issue, Kind: "repository", Name: owner + "/" + repo, Err: err})
+			}
+			continue
+		}
+
+		batchTitles := make(map[string]bool, len(group.Issues))
+		for _, issue := range group.Issues {
+			batchTitles[normalizeName(issue.Title)] = true
+		}
+
+		defaultType := c.defaultTypeFor(ctx, owner, repo, opts.DefaultType)
+
+		labelsCreated := make(map[string]bool)
+		placeholders := make(map[string]bool)
+		for _, issue := range group.Issues {
+			if strings.TrimSpace(issue.Type) == "" {
+				issue.Type = defaultType
+			}
+			c.dryRunIssue(ctx, owner, repo, issue, opts, batchTitles, planned, labelsCreated, placeholders, report)
+		}
+	}
+	return report
+}
+
+// dryRunIssue adds the problems and planned actions of a single issue to report. labelsCreated and
+// placeholders hold the labels and placeholder parents already planned, so each is planned once.
+func (c *Client) dryRunIssue(ctx context.Context, owner, repo string, issue issuemanager.Issue, opts CreateOptions, batchTitles, plannedLabels, labelsCreated, placeholders map[string]bool, report *DryRunReport) {
+	problem := func(kind, name string, err error) {
+		report.Problems = append(report.Problems, ReferenceProblem{Issue: issue, Kind: kind, Name: name, Err: err})
+	}
+	action := func(format string, args ...interface{}) {
+		report.Actions = append(report.Actions, PlannedAction{Issue: issue, Description: fmt.Sprintf(format, args...)})
+	}
+
+	var details []string
+	if issueType := strings.TrimSpace(issue.Type); issueType != "" {
+		details = append(details, "type "+issueType)
+	}
+	if len(issue.Labels) > 0 {
+		details = append(details, "labels "+strings.Join(issue.Labels, ", "))
+	}
+	if len(issue.Assignees) > 0 {
+		details = append(details, "assignees "+strings.Join(issue.Assignees, ", "))
+	}
+	summary := ""
+	if len(details) > 0 {
+		summary = " (" + strings.Join(details, "; ") + ")"
+	}
+	if issue.Id == "" {
+		action("create issue in %s/%s%s", owner, repo, summary)
+	} else {
+		action("update issue #%s in %s/%s%s", issue.Id, owner, repo, summary)
+	}
+
+	if strings.TrimSpace(issue.Type) != "" {
+		if _, err := c.ResolveIssueTypeID(ctx, owner, repo, issue.Type); err != nil {
+			problem("type", issue.Type, err)
+		}
+	}
+
+	if len(issue.Labels) > 0 {
+		labels, err := c.repoLabels(ctx, owner, repo, issue.Labels...)
+		if err != nil {
+			problem("label", strings.Join(issue.Labels, ", "), err)
+		} else {
+			for _, label := range issue.Labels {
+				name := normalizeName(label)
+				switch {
+				case labels[name] != "":
+				case plannedLabels[name] || c.CreateMissingLabels:
+					if !labelsCreated[name] {
+						labelsCreated[name] = true
+						action("create label %q", strings.TrimSpace(label))
+					}
+				default:
+					problem("label", label, fmt.Errorf("label not found in %s/%s", owner, repo))
+				}
+			}
+		}
+	}
+
+	// A real run skips unknown assignees with a warning; report them here before anything is created
+	for _, login := range issue.Assignees {
+		if _, err := c.ResolveUserID(ctx, login); err != nil {
+			problem("assignee", login, err)
+		}
+	}
+
+	if parent := strings.TrimSpace(issue.Parent); parent != "" {
+		if batchTitles[normalizeName(parent)] {
+			action("link to parent %q (in this batch)", parent)
+		} else if placeholders[normalizeName(parent)] {
+			action("link to parent %q", parent)
+		} else if _, err := c.ResolveParentIssueID(ctx, owner, repo, parent); err == nil {
+			action("link to parent %q", parent)
+		} else if opts.CreateMissingParents && errors.Is(err, ErrParentNotFound) {
+			placeholders[normalizeName(parent)] = true
+			action("create placeholder parent %q", parent)
+			action("link to parent %q", parent)
+		} else {
+			problem("parent", parent, err)
+		}
+	}
+
+	if strings.TrimSpace(issue.Project) == "" {
+		return
+	}
+	projectNodeID, err := c.ResolveProjectID(ctx, owner, issue.Project)
+	if err != nil {
+		problem("project", issue.Project, err)
+		return
+	}
+	action("add to project %q", issue.Project)
+
+	for _, field := range issue.ProjectFields {
+		definition, err := c.resolveProjectField(ctx, projectNodeID, field.Field)
+		if err != nil {
+			problem("project field", field.Field, err)
+			continue
+		}
+		if _, err := c.projectFieldValue(definition, field.Value); err != nil {
+			problem("project field", field.Field+"="+field.Value, err)
+			continue
+		}
+		action("set project field %s=%s", definition.Name, field.Value)
+	}
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+func TestDryRunSendsNoMutations(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	c.CreateMissingLabels = true
+	f.reply("labels(first: $first", obj{"repository": obj{"labels": obj{
+		"pageInfo": obj{"hasNextPage": false},
+		"nodes":    []obj{{"id": "L_bug", "name": "bug"}},
+	}}})
+	f.reply("issueTypes(first", obj{"repository": obj{"issueTypes": obj{"nodes": []obj{{"id": "T_task", "name": "Task"}}}}})
+	f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+	replySearch(f, "I_epic", "Epic")
+	replyUsers(f, map[string]string{"octocat": "U_octocat"})
+	replyProjects(f, "Roadmap")
+	f.reply("field(name", obj{"node": obj{"field": obj{
+		"id": "F_status", "name": "Status", "dataType": "SINGLE_SELECT",
+		"options": []obj{{"id": "O_todo", "name": "Todo"}},
+	}}})
+
+	issues := []issuemanager.Issue{
+		{
+			Title: "Child", Type: "Task", Labels: []string{"bug", "new label"}, Assignees: []string{"octocat", "ghost"},
+			Parent: "Epic", Project: "Roadmap", ProjectFields: []issuemanager.ProjectFieldValue{{Field: "Status", Value: "Todo"}},
+		},
+		{Title: "Existing", Id: "7", Parent: "Child"},
+	}
+	report := c.DryRun(context.Background(), "octo", "hello", issues, nil, CreateOptions{})
+
+	if mutations := f.mutations(); len(mutations) != 0 {
+		t.Errorf("dry run sent %d mutations, want none", len(mutations))
+	}
+	if len(report.Problems) != 1 || report.Problems[0].Kind != "assignee" || report.Problems[0].Name != "ghost" {
+		t.Errorf("problems = %+v, want one for assignee ghost", report.Problems)
+	}
+
+	var actions []string
+	for _, action := range report.Actions {
+		actions = append(actions, action.Description)
+	}
+	for _, want := range []string{
+		`create label "new label"`,
+		`link to parent "Epic"`,
+		`link to parent "Child" (in this batch)`,
+		`add to project "Roadmap"`,
+		"set project field Status=Todo",
+		"update issue #7 in octo/hello",
+	} {
+		if !containsString(actions, want) {
+			t.Errorf("actions %q missing %q", actions, want)
+		}
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDryRunDefaultType(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultType string
+		want        []string
+	}{
+		{
+			name:        "default type applies to untyped issues",
+			defaultType: "task",
+			want:        []string{"create issue in octo/hello (type task)", "create issue in octo/hello (type Bug)"},
+		},
+		{
+			name:        "unknown default type falls back to plain issues",
+			defaultType: "Epic",
+			want:        []string{"create issue in octo/hello", "create issue in octo/hello (type Bug)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+			f.reply("issueTypes(first", obj{"repository": obj{"issueTypes": obj{"nodes": []obj{
+				{"id": "T_task", "name": "Task"}, {"id": "T_bug", "name": "Bug"},
+			}}}})
+
+			issues := []issuemanager.Issue{{Title: "Untyped"}, {Title: "Typed", Type: "Bug"}}
+			report := c.DryRun(context.Background(), "octo", "hello", issues, nil, CreateOptions{DefaultType: tt.defaultType})
+
+			if len(report.Problems) != 0 {
+				t.Errorf("problems = %+v, want none", report.Problems)
+			}
+			var actions []string
+			for _, action := range report.Actions {
+				actions = append(actions, action.Description)
+			}
+			if !reflect.DeepEqual(actions, tt.want) {
+				t.Errorf("actions = %q, want %q", actions, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRunPlansPlaceholderParents(t *testing.T) {
+	tests := []struct {
+		name         string
+		createParent bool
+		search       interface{}
+		wantActions  []string
+		wantProblems int
+	}{
+		{
+			name:         "missing parent is planned once",
+			createParent: true,
+			search:       obj{"search": obj{"nodes": []obj{}}},
+			wantActions:  []string{`create placeholder parent "Epic"`, `link to parent "Epic"`, `link to parent "Epic"`},
+		},
+		{
+			name:         "missing parent without the flag",
+			search:       obj{"search": obj{"nodes": []obj{}}},
+			wantProblems: 2,
+		},
+		{
+			name:         "failed parent lookup",
+			createParent: true,
+			search:       fakeErrors{"Something went wrong while executing your query."},
+			wantProblems: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+			f.reply("search(query", tt.search)
+
+			issues := []issuemanager.Issue{{Title: "One", Parent: "Epic"}, {Title: "Two", Parent: "Epic"}}
+			report := c.DryRun(context.Background(), "octo", "hello", issues, nil, CreateOptions{CreateMissingParents: tt.createParent})
+
+			var actions []string
+			for _, action := range report.Actions {
+				if !strings.HasPrefix(action.Description, "create issue") {
+					actions = append(actions, action.Description)
+				}
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("actions = %q, want %q", actions, tt.wantActions)
+			}
+			if len(report.Problems) != tt.wantProblems {
+				t.Errorf("problems = %+v, want %d", report.Problems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github-issue-manager/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(logger.ErrorLevel, false)
+	os.Exit(m.Run())
+}
+
+// fakeRequest is a GraphQL request received by fakeGitHub.
+type fakeRequest struct {
+	Query     string
+	Variables map[string]interface{}
+	Header    http.Header
+}
+
+// input returns the $input variable of a mutation.
+func (r fakeRequest) input() map[string]interface{} {
+	input, _ := r.Variables["input"].(map[string]interface{})
+	return input
+}
+
+// fakeStatus makes fakeGitHub answer with an HTTP error status.
+type fakeStatus struct {
+	code       int
+	message    string
+	retryAfter string
+}
+
+// fakeErrors makes fakeGitHub answer 200 with a GraphQL errors array.
+type fakeErrors []string
+
+// fakeHandler answers the requests whose query contains match. It returns the "data" object, a
+// fakeStatus or fakeErrors.
+type fakeHandler struct {
+	match string
+	fn    func(r fakeRequest) interface{}
+}
+
+// fakeGitHub is an in-memory GraphQL endpoint. Requests are routed to the first handler whose
+// match string occurs in the query and recorded for later assertions.
+type fakeGitHub struct {
+	t        *testing.T
+	mu       sync.Mutex
+	handlers []fakeHandler
+	requests []fakeRequest
+}
+
+// newFakeGitHub starts a fake GraphQL server and returns it with a client pointed at it.
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *Client) {
+	t.Helper()
+	f := &fakeGitHub{t: t}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+
+	endpoint := GraphQLEndpoint
+	GraphQLEndpoint = srv.URL
+	t.Cleanup(func() { GraphQLEndpoint = endpoint })
+
+	return f, NewClient(context.Background(), "test-token")
+}
+
+// on registers fn for queries containing match.
+func (f *fakeGitHub) on(match string, fn func(r fakeRequest) interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers = append(f.handlers, fakeHandler{match: match, fn: fn})
+}
+
+// reply registers a fixed data response for queries containing match.
+func (f *fakeGitHub) reply(match string, data interface{}) {
+	f.on(match, func(fakeRequest) interface{} { return data })
+}
+
+// calls returns the recorded requests whose query contains match.
+func (f *fakeGitHub) calls(match string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []fakeRequest
+	for _, r := range f.requests {
+		if strings.Contains(r.Query, match) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// mutations returns the recorded mutations.
+func (f *fakeGitHub) mutations() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []fakeRequest
+	for _, r := range f.requests {
+		if strings.HasPrefix(strings.TrimSpace(r.Query), "mutation") {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r := fakeRequest{Query: body.Query, Variables: body.Variables, Header: req.Header.Clone()}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	var handler *fakeHandler
+	for i := range f.handlers {
+		if strings.Contains(r.Query, f.handlers[i].match) {
+			handler = &f.handlers[i]
+			break
+		}
+	}
+	f.mu.Unlock()
+
+	if handler == nil {
+		f.t.Errorf("unexpected GraphQL request: %s", r.Query)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"errors": []map[string]string{{"message": "no fake handler"}}})
+		return
+	}
+
+	switch resp := handler.fn(r).(type) {
+	case fakeStatus:
+		if resp.retryAfter != "" {
+			w.Header().Set("Retry-After", resp.retryAfter)
+		}
+		writeJSON(w, resp.code, map[string]string{"message": resp.message})
+	case fakeErrors:
+		var errs []map[string]string
+		for _, message := range resp {
+			errs = append(errs, map[string]string{"message": message})
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": nil, "errors": errs})
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": resp})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// obj is shorthand for the nested maps fake responses are built from.
+type obj = map[string]interface{}
+
+// replyCreateIssue answers createIssue mutations with consecutive issue numbers.
+func replyCreateIssue(f *fakeGitHub) {
+	number := 0
+	f.on("createIssue", func(fakeRequest) interface{} {
+		number++
+		return obj{"createIssue": obj{"issue": obj{"id": fmt.Sprintf("I_%d", number), "number": number}}}
+	})
+}
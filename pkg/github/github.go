@@ -2,12 +2,15 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github-issue-manager/pkg/issuemanager"
 	"github-issue-manager/pkg/logger"
@@ -15,15 +18,36 @@ import (
 	"github.com/machinebox/graphql"
 )
 
+// DefaultLabelColor is the color used for labels created without an explicit color.
+const DefaultLabelColor = "ededed"
+
 // Client holds the GitHub GraphQL client.
 type Client struct {
 	GraphQL *graphql.Client
+
+	// CreateMissingLabels makes label resolution create labels that don't exist yet instead of dropping them.
+	CreateMissingLabels bool
+	// PruneLabels makes updates remove labels that are no longer in an issue's front matter.
+	PruneLabels bool
+	// QuietSuccess silences the per-issue progress lines of successful creates and updates.
+	QuietSuccess bool
+	// ForceStatus makes re-runs overwrite the project Status of items that already have one. Without
+	// it a Status from the file is only applied while the item has none, i.e. when the issue is
+	// first added to the project.
+	ForceStatus bool
+
+	cache   *resolveCache
+	retries *retryBudget
+
+	typeAliases   map[string]string // normalized local type name -> repository type name
+	statusAliases map[string]string // normalized local status -> project Status option name
 }
 
 // IssueResult represents the result of creating an issue.
 type IssueResult struct {
 	Number int64  // Issue number (e.g. 123)
 	NodeID string // GraphQL node ID (needed for Projects v2)
+	URL    string
 	Err    error
 }
 
@@ -47,9 +71,13 @@ type ProjectField struct {
 
 // RepositoryInfo holds information about a GitHub repository.
 type RepositoryInfo struct {
-	Labels        []Label        `json:"labels"`
-	IssueTypes    []IssueType    `json:"issueTypes"`
-	ProjectFields []ProjectField `json:"projectFields"`
+	PrimaryLanguage string         `json:"primaryLanguage,omitempty"`
+	Topics          []string       `json:"topics"`
+	License         string         `json:"license,omitempty"`
+	IsArchived      bool           `json:"isArchived"`
+	Labels          []Label        `json:"labels"`
+	IssueTypes      []IssueType    `json:"issueTypes"`
+	ProjectFields   []ProjectField `json:"projectFields"`
 }
 
 // OPTIONAL: ensure your issue model has a Type field.
@@ -87,9 +115,22 @@ func ReadTokenFromHostsFile() (string, error) {
 	return "", fmt.Errorf("oauth_token not found in hosts file")
 }
 
-// --- NEW: helper to get a token (env first, then gh hosts.yml)
-func (c *Client) getToken() (string, error) {
+// TokenFromEnv returns the token from GITHUB_TOKEN, or from GH_TOKEN when running in GitHub Actions
+// (where workflows commonly expose the job token under that name).
+func TokenFromEnv() string {
 	if t := os.Getenv("GITHUB_TOKEN"); t != "" {
+		return t
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		return os.Getenv("GH_TOKEN")
+	}
+	return ""
+}
+
+// getToken returns the token clients authenticate with when NewClient got none: the environment
+// first, then the gh hosts file.
+func getToken() (string, error) {
+	if t := TokenFromEnv(); t != "" {
 		return t, nil
 	}
 	if hostsToken, err := ReadTokenFromHostsFile(); err == nil && hostsToken != "" {
@@ -98,118 +139,561 @@ func (c *Client) getToken() (string, error) {
 	return "", fmt.Errorf("GITHUB_TOKEN not set and hosts file token not found")
 }
 
-// CreateIssues creates multiple GitHub issues in dependency order.
-func (c *Client) CreateIssues(ctx context.Context, owner, repo string, issues []issuemanager.Issue) {
+// CreateOptions controls how CreateIssues processes a batch of issues.
+type CreateOptions struct {
+	// IDPosition controls where a new id line is inserted into the front matter.
+	IDPosition issuemanager.IDPosition
+	// UnlinkRemovedParents removes the GitHub parent of existing issues whose file no longer specifies one.
+	UnlinkRemovedParents bool
+	// ClearRemovedTypes clears the GitHub issue type of existing issues whose file no longer specifies one.
+	ClearRemovedTypes bool
+	// NoWriteID leaves issue files untouched instead of writing new issue numbers back into them.
+	NoWriteID bool
+	// SleepBetween pauses between issues to stay under secondary rate limits on large imports.
+	SleepBetween time.Duration
+	// DefaultType is the issue type given to issues without an explicit type. It is skipped with a
+	// warning in repositories where it doesn't resolve (e.g. issue types aren't enabled).
+	DefaultType string
+	// CreateMissingParents creates a title-only placeholder issue for a parent that is neither in the
+	// batch nor on GitHub, so its children aren't created unlinked.
+	CreateMissingParents bool
+	// PlaceholderLabel and PlaceholderType are applied to placeholder parents when set.
+	PlaceholderLabel string
+	PlaceholderType  string
+	// CommentOnUpdate posts a comment listing the changed fields on each updated issue that changed.
+	CommentOnUpdate bool
+	// OnProcessed, when set, is called as soon as each input issue has been created or updated
+	// successfully, e.g. to checkpoint progress.
+	OnProcessed func(CreateResult)
+}
+
+// CreateResult records the outcome of processing a single issue in a batch.
+type CreateResult struct {
+	Issue   issuemanager.Issue
+	Number  int64
+	URL     string
+	Created bool // true when a new issue was created, false when an existing one was updated
+	// Placeholder marks a title-only parent created by CreateMissingParents rather than from the input
+	Placeholder bool
+	Err         error
+	// WriteIDErr is set when the issue was created but its number couldn't be written back to its
+	// file; the next run would create a duplicate unless the id is added by hand.
+	WriteIDErr error
+}
+
+// CreateIssues creates multiple GitHub issues in dependency order and returns the outcome of each.
+func (c *Client) CreateIssues(ctx context.Context, owner, repo string, issues []issuemanager.Issue, opts CreateOptions) []CreateResult {
 	// Sort issues so parent issues are created before children
-	sortedIssues := issuemanager.SortIssuesByDependency(issues)
+	sortedIssues, err := issuemanager.SortIssuesByDependency(issues)
+	if err != nil {
+		// Nothing is created when the parent links can't be ordered
+		var results []CreateResult
+		for _, issue := range issues {
+			results = append(results, CreateResult{Issue: issue, Err: err})
+		}
+		return results
+	}
 
 	// Map to store created issue numbers by title for parent-child linking
 	createdIssues := make(map[string]int64)
-
-	for _, issue := range sortedIssues {
-		// if the id isn't in the file then it's not in github
-		var issueResponse IssueResult
-		if issue.Id == "" {
-			// Use GraphQL with Issue Type when provided, otherwise use standard GraphQL creation
-			if strings.TrimSpace(issue.Type) != "" {
-				issueResponse = c.CreateIssueWithTypeGraphQL(ctx, owner, repo, issue)
-			} else {
-				issueResponse = c.CreateIssue(ctx, owner, repo, issue) // GraphQL creation
+	var results []CreateResult
+
+	// Process issues one target repository at a time so each repository's IDs, labels and types
+	// are resolved once and cached instead of alternating between repositories
+	groups := groupIssuesByTarget(sortedIssues, owner, repo)
+	processed := 0
+	for _, group := range groups {
+		owner, repo := group.Owner, group.Repo
+		if len(groups) > 1 {
+			fmt.Printf("Processing %d issues in %s/%s\n", len(group.Issues), owner, repo)
+		}
+		if _, err := c.ResolveRepositoryID(ctx, owner, repo); err != nil {
+			logger.Error("Failed to resolve repository, skipping its issues", "owner", owner, "repo", repo, "error", err)
+			for _, issue := range group.Issues {
+				results = append(results, CreateResult{Issue: issue, Created: issue.Id == "", Err: err})
 			}
+			continue
+		}
 
-			// Read the markdown file
-			filePath := filepath.Join(issue.Path, issue.FileName)
-			data, err := ioutil.ReadFile(filePath)
-			if err != nil {
-				logger.Error("Failed to read markdown file", "file", filePath, "error", err)
-				continue
-			} // Update the front matter with the new issue ID
-			lines := strings.Split(string(data), "\n")
-			idFound := false
-			for i, line := range lines {
-				if strings.HasPrefix(line, "id:") {
-					lines[i] = "id: " + strconv.FormatInt(issueResponse.Number, 10)
-					idFound = true
-					break
+		defaultType := c.defaultTypeFor(ctx, owner, repo, opts.DefaultType)
+
+		batchTitles := make(map[string]bool, len(group.Issues))
+		for _, issue := range group.Issues {
+			batchTitles[normalizeName(issue.Title)] = true
+		}
+
+		for i, issue := range group.Issues {
+			if strings.TrimSpace(issue.Type) == "" {
+				issue.Type = defaultType
+			}
+			if processed > 0 && opts.SleepBetween > 0 {
+				logger.Debug("Sleeping between issues", "duration", opts.SleepBetween)
+				select {
+				case <-ctx.Done():
+				case <-time.After(opts.SleepBetween):
 				}
 			}
-
-			if !idFound {
-				frontMatterEnd := 0
-				for i, line := range lines {
-					if strings.TrimSpace(line) == "---" {
-						if frontMatterEnd == 0 {
-							frontMatterEnd = i
-						} else {
-							break
-						}
-					}
+			if err := ctx.Err(); err != nil {
+				// Cancelled or timed out: report the rest as failed so they are retried on the next run
+				for _, skipped := range group.Issues[i:] {
+					results = append(results, CreateResult{Issue: skipped, Created: skipped.Id == "", Err: err})
 				}
-				if frontMatterEnd != 0 {
-					lines = append(
-						lines[:frontMatterEnd],
-						append([]string{"id: " + strconv.FormatInt(issueResponse.Number, 10)}, lines[frontMatterEnd:]...)...,
-					)
-				} else {
-					lines = append(lines, "id: "+strconv.FormatInt(issueResponse.Number, 10))
+				break
+			}
+			processed++
+
+			if opts.CreateMissingParents && strings.TrimSpace(issue.Parent) != "" && !batchTitles[normalizeName(issue.Parent)] {
+				result, created, err := c.ensureParent(ctx, owner, repo, issue.Parent, opts)
+				if err != nil {
+					// Creating the issue now would leave it unlinked; fail it so a re-run retries it
+					logger.Error("Failed to look up parent issue", "issue", issue.Title, "parent", issue.Parent, "error", err)
+					results = append(results, CreateResult{Issue: issue, Created: issue.Id == "", Err: fmt.Errorf("look up parent %q: %w", issue.Parent, err)})
+					continue
+				}
+				if created {
+					results = append(results, result)
 				}
 			}
 
-			// Write back to the markdown file
-			err = ioutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644)
-			if err != nil {
-				logger.Error("Failed to update markdown file", "file", filePath, "error", err)
+			if issue.ExternalID != "" {
+				issue.Body = issuemanager.WithExternalIDMarker(issue.Body, issue.ExternalID)
+				if issue.Id == "" {
+					if err := c.recoverIDByExternalID(ctx, owner, repo, &issue, opts); err != nil {
+						// Creating the issue now could duplicate the one carrying the marker; fail it so a re-run retries it
+						logger.Error("Failed to look up issue by external id", "issue", issue.Title, "external_id", issue.ExternalID, "error", err)
+						results = append(results, CreateResult{Issue: issue, Created: true, Err: fmt.Errorf("look up external id %q: %w", issue.ExternalID, err)})
+						continue
+					}
+				}
 			}
-		} else {
-			fmt.Printf("Issue '%s' already exists (#%s), updating...\n", issue.Title, issue.Id)
-			idInt, err := strconv.ParseInt(issue.Id, 10, 64)
-			if err != nil {
-				logger.Error("Failed to convert issue ID to int64", "id", issue.Id, "error", err)
-				issueResponse = IssueResult{Number: 0, Err: err}
-			} else {
-				// Update the existing issue
+
+			// if the id isn't in the file then it's not in github
+			var issueResponse IssueResult
+			if issue.Id == "" {
+				// Use GraphQL with Issue Type when provided, otherwise use standard GraphQL creation
 				if strings.TrimSpace(issue.Type) != "" {
-					issueResponse = c.UpdateIssueWithTypeGraphQL(ctx, owner, repo, issue, idInt)
+					issueResponse = c.CreateIssueWithTypeGraphQL(ctx, owner, repo, issue)
 				} else {
-					issueResponse = c.UpdateIssue(ctx, owner, repo, issue, idInt)
+					issueResponse = c.CreateIssue(ctx, owner, repo, issue) // GraphQL creation
 				}
 
+				results = append(results, CreateResult{Issue: issue, Number: issueResponse.Number, URL: issueResponse.URL, Created: true, Err: issueResponse.Err})
 				if issueResponse.Err != nil {
-					logger.Error("Failed to update issue", "issue", issue.Title, "error", issueResponse.Err)
+					logger.Error("Failed to create issue", "issue", issue.Title, "error", issueResponse.Err)
+					continue
+				}
+
+				// Update the front matter with the new issue ID (issues without a file, e.g. from a titles file, have nothing to update)
+				if issue.FileName != "" && !opts.NoWriteID {
+					filePath := filepath.Join(issue.Path, issue.FileName)
+					if err := issuemanager.WriteIssueID(filePath, issueResponse.Number, opts.IDPosition); err != nil {
+						logger.Error("Failed to update markdown file", "file", filePath, "error", err)
+						results[len(results)-1].WriteIDErr = err
+					}
+				}
+			} else {
+				c.progressf("Issue '%s' already exists (#%s), updating...\n", issue.Title, issue.Id)
+				idInt, err := strconv.ParseInt(issue.Id, 10, 64)
+				if err != nil {
+					logger.Error("Failed to convert issue ID to int64", "id", issue.Id, "error", err)
+					issueResponse = IssueResult{Number: 0, Err: err}
 				} else {
-					fmt.Printf("Successfully updated issue '%s' (#%d)\n", issue.Title, issueResponse.Number)
+					// Snapshot the issue before updating so the comment can list what changed
+					var before *IssueDetails
+					if opts.CommentOnUpdate {
+						if before, err = c.GetIssue(ctx, owner, repo, idInt); err != nil {
+							logger.Warn("Failed to fetch issue before update, skipping change comment", "issue", issue.Title, "error", err)
+						}
+					}
+
+					// Update the existing issue
+					if strings.TrimSpace(issue.Type) != "" || opts.ClearRemovedTypes {
+						issueResponse = c.UpdateIssueWithTypeGraphQL(ctx, owner, repo, issue, idInt)
+					} else {
+						issueResponse = c.UpdateIssue(ctx, owner, repo, issue, idInt)
+					}
+
+					if issueResponse.Err != nil {
+						logger.Error("Failed to update issue", "issue", issue.Title, "error", issueResponse.Err)
+					} else {
+						c.progressf("Successfully updated issue '%s' (#%d)\n", issue.Title, issueResponse.Number)
+
+						if opts.UnlinkRemovedParents && strings.TrimSpace(issue.Parent) == "" {
+							c.unlinkCurrentParent(ctx, owner, repo, issue, issueResponse)
+						}
+
+						if before != nil {
+							c.commentChanges(ctx, issue, before, issueResponse)
+						}
+					}
 				}
+				results = append(results, CreateResult{Issue: issue, Number: issueResponse.Number, URL: issueResponse.URL, Err: issueResponse.Err})
+			}
+
+			// Store the created/updated issue number for parent-child linking; the node ID lets
+			// children resolve this parent before GitHub's search index catches up
+			if issueResponse.Err == nil {
+				createdIssues[issue.Title] = issueResponse.Number
+				c.cache.setIssueID(owner, repo, issue.Title, issueResponse.NodeID)
+			}
+
+			// Add issue to project if project name is provided
+			if issue.Project != "" {
+				c.addToProject(ctx, owner, repo, issue, issueResponse.Number)
 			}
+
+			if opts.OnProcessed != nil && issueResponse.Err == nil {
+				opts.OnProcessed(results[len(results)-1])
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		fmt.Printf("Stopped early (%v) after processing %d of %d issues.\n", err, processed, len(sortedIssues))
+	}
+	fmt.Printf("Created %d issues successfully.\n", len(createdIssues))
+	return results
+}
+
+// recoverIDByExternalID sets the id of an issue whose file lost it when an issue carrying its
+// external id marker already exists, so the issue is updated instead of duplicated. The recovered
+// id is written back to the file. A failed lookup is returned so the caller doesn't create an issue
+// that may already exist.
+func (c *Client) recoverIDByExternalID(ctx context.Context, owner, repo string, issue *issuemanager.Issue, opts CreateOptions) error {
+	number, err := c.FindIssueByExternalID(ctx, owner, repo, issue.ExternalID)
+	if err != nil {
+		return err
+	}
+	if number == 0 {
+		return nil
+	}
+
+	c.progressf("Found existing issue #%d for external id %q\n", number, issue.ExternalID)
+	issue.Id = strconv.FormatInt(number, 10)
+	if issue.FileName != "" && !opts.NoWriteID {
+		filePath := filepath.Join(issue.Path, issue.FileName)
+		if err := issuemanager.WriteIssueID(filePath, number, opts.IDPosition); err != nil {
+			logger.Warn("Failed to write recovered id to markdown file", "file", filePath, "error", err)
 		}
+	}
+	return nil
+}
+
+// FindIssueByExternalID returns the number of the issue in owner/repo whose body carries the
+// external id marker, or 0 if there is none.
+func (c *Client) FindIssueByExternalID(ctx context.Context, owner, repo, externalID string) (int64, error) {
+	query := fmt.Sprintf("repo:%s/%s is:issue in:body %q", owner, repo, "external-id: "+externalID)
+	candidates, err := c.SearchIssues(ctx, query, 10)
+	if err != nil {
+		return 0, err
+	}
 
-		// Store the created/updated issue number for parent-child linking
-		if issueResponse.Err == nil {
-			createdIssues[issue.Title] = issueResponse.Number
+	// Search matches words loosely; confirm the exact marker is in the body
+	marker := issuemanager.ExternalIDMarker(externalID)
+	for _, candidate := range candidates {
+		details, err := c.GetIssue(ctx, owner, repo, int64(candidate.Number))
+		if err != nil {
+			return 0, err
+		}
+		if strings.Contains(details.Body, marker) {
+			return details.Number, nil
 		}
+	}
+	return 0, nil
+}
 
-		// Add issue to project if project name is provided
-		if issue.Project != "" {
-			// Get issue node id by issue number using GraphQL
-			issueNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, issueResponse.Number)
-			if err != nil {
-				fmt.Println("Error resolving issue node ID:", err)
-				continue
+// ensureParent creates a title-only placeholder issue for parentTitle unless it already exists in
+// the repository. created reports whether a placeholder was attempted; result holds its outcome.
+// A lookup that fails for any other reason than the parent not existing is returned as err
+// without creating anything, so a search outage doesn't duplicate parents.
+func (c *Client) ensureParent(ctx context.Context, owner, repo, parentTitle string, opts CreateOptions) (result CreateResult, created bool, err error) {
+	if _, err := c.ResolveParentIssueID(ctx, owner, repo, parentTitle); err == nil {
+		return CreateResult{}, false, nil
+	} else if !errors.Is(err, ErrParentNotFound) {
+		return CreateResult{}, false, err
+	}
+
+	placeholder := issuemanager.Issue{Title: strings.TrimSpace(parentTitle), Type: opts.PlaceholderType}
+	if opts.PlaceholderLabel != "" {
+		placeholder.Labels = []string{opts.PlaceholderLabel}
+	}
+
+	var response IssueResult
+	if strings.TrimSpace(placeholder.Type) != "" {
+		response = c.CreateIssueWithTypeGraphQL(ctx, owner, repo, placeholder)
+	} else {
+		response = c.CreateIssue(ctx, owner, repo, placeholder)
+	}
+	if response.Err != nil {
+		logger.Error("Failed to create placeholder parent issue", "parent", parentTitle, "error", response.Err)
+		return CreateResult{Issue: placeholder, Created: true, Placeholder: true, Err: response.Err}, true, nil
+	}
+
+	logger.Warn("Parent not found; created a placeholder parent issue", "parent", parentTitle, "number", response.Number, "owner", owner, "repo", repo)
+	c.cache.setIssueID(owner, repo, placeholder.Title, response.NodeID)
+	return CreateResult{Issue: placeholder, Number: response.Number, URL: response.URL, Created: true, Placeholder: true}, true, nil
+}
+
+// defaultTypeFor returns typeName if it resolves in the repository. Otherwise it warns and returns
+// an empty string so issues without a type are created as plain issues.
+func (c *Client) defaultTypeFor(ctx context.Context, owner, repo, typeName string) string {
+	if strings.TrimSpace(typeName) == "" {
+		return ""
+	}
+	if _, err := c.ResolveIssueTypeID(ctx, owner, repo, typeName); err != nil {
+		logger.Warn("Default issue type unavailable, creating untyped issues as plain issues", "type", typeName, "owner", owner, "repo", repo, "error", err)
+		return ""
+	}
+	return typeName
+}
+
+// issueGroup is a run of issues that share a target repository.
+type issueGroup struct {
+	Owner  string
+	Repo   string
+	Issues []issuemanager.Issue
+}
+
+// groupIssuesByTarget groups issues by target repository, keeping the groups in order of first
+// appearance and the issues within each group in their original (dependency) order.
+func groupIssuesByTarget(issues []issuemanager.Issue, defaultOwner, defaultRepo string) []issueGroup {
+	var groups []issueGroup
+	index := make(map[string]int)
+	for _, issue := range issues {
+		owner, repo := issue.Target(defaultOwner, defaultRepo)
+		key := repoKey(owner, repo)
+		n, ok := index[key]
+		if !ok {
+			n = len(groups)
+			index[key] = n
+			groups = append(groups, issueGroup{Owner: owner, Repo: repo})
+		}
+		groups[n].Issues = append(groups[n].Issues, issue)
+	}
+	return groups
+}
+
+// commentChanges posts a comment on an updated issue listing the fields that differ from before.
+// Nothing is posted when the update changed nothing.
+func (c *Client) commentChanges(ctx context.Context, issue issuemanager.Issue, before *IssueDetails, result IssueResult) {
+	changes := issueChanges(before, issue)
+	if len(changes) == 0 {
+		logger.Debug("Issue unchanged, skipping change comment", "issue", issue.Title)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("Updated from markdown:\n")
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s\n", change)
+	}
+	if err := c.AddComment(ctx, result.NodeID, b.String()); err != nil {
+		logger.Warn("Failed to comment on updated issue", "issue", issue.Title, "error", err)
+	}
+}
+
+// issueChanges describes the fields of issue that differ from the issue's state before the update.
+func issueChanges(before *IssueDetails, issue issuemanager.Issue) []string {
+	var changes []string
+	if strings.TrimSpace(before.Title) != strings.TrimSpace(issue.Title) {
+		changes = append(changes, fmt.Sprintf("title: %q → %q", before.Title, issue.Title))
+	}
+	if strings.TrimSpace(before.Body) != strings.TrimSpace(issue.Body) {
+		changes = append(changes, "body")
+	}
+	if issue.Type != "" && !strings.EqualFold(before.IssueType, strings.TrimSpace(issue.Type)) {
+		changes = append(changes, fmt.Sprintf("type: %q → %q", before.IssueType, issue.Type))
+	}
+
+	current := make(map[string]bool, len(before.Labels))
+	for _, label := range before.Labels {
+		current[normalizeName(label)] = true
+	}
+	var added []string
+	for _, label := range issue.Labels {
+		if !current[normalizeName(label)] {
+			added = append(added, label)
+		}
+	}
+	if len(added) > 0 {
+		changes = append(changes, "labels added: "+strings.Join(added, ", "))
+	}
+
+	beforeParent := ""
+	if before.Parent != nil {
+		beforeParent = before.Parent.Title
+	}
+	if issue.Parent != "" && !strings.EqualFold(strings.TrimSpace(beforeParent), strings.TrimSpace(issue.Parent)) {
+		changes = append(changes, fmt.Sprintf("parent: %q → %q", beforeParent, issue.Parent))
+	}
+	return changes
+}
+
+// AddComment posts a comment on an issue (or any other commentable node).
+func (c *Client) AddComment(ctx context.Context, subjectID, body string) error {
+	req := graphql.NewRequest(`
+		mutation($input: AddCommentInput!) {
+			addComment(input: $input) {
+				commentEdge { node { id } }
 			}
+		}
+	`)
+	req.Var("input", map[string]interface{}{
+		"subjectId": subjectID,
+		"body":      body,
+	})
 
-			// Resolve project name to GraphQL ID
-			logger.Debug("Resolving project name to GraphQL node ID", "project", issue.Project)
-			projectNodeID, err := c.ResolveProjectID(ctx, owner, issue.Project)
-			if err != nil {
-				fmt.Println("Error resolving project ID:", err)
+	var resp struct {
+		AddComment struct {
+			CommentEdge struct {
+				Node struct {
+					ID string `json:"id"`
+				} `json:"node"`
+			} `json:"commentEdge"`
+		} `json:"addComment"`
+	}
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return fmt.Errorf("addComment GraphQL failed: %w", err)
+	}
+	return nil
+}
+
+// CloseIssue closes an issue as completed.
+func (c *Client) CloseIssue(ctx context.Context, issueID string) error {
+	req := graphql.NewRequest(`
+		mutation($input: CloseIssueInput!) {
+			closeIssue(input: $input) {
+				issue { id state }
 			}
-			// Add issue to project
-			if err := c.AddIssueToProject(ctx, issueNodeID, projectNodeID); err != nil {
-				fmt.Printf("Failed to add issue %s to project '%s': %v\n", issue.Title, issue.Project, err)
+		}
+	`)
+	req.Var("input", map[string]interface{}{
+		"issueId":     issueID,
+		"stateReason": "COMPLETED",
+	})
+
+	var resp struct {
+		CloseIssue struct {
+			Issue struct {
+				ID    string `json:"id"`
+				State string `json:"state"`
+			} `json:"issue"`
+		} `json:"closeIssue"`
+	}
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return fmt.Errorf("closeIssue GraphQL failed: %w", err)
+	}
+	return nil
+}
+
+// unlinkCurrentParent removes the GitHub parent of an issue whose file no longer names one.
+func (c *Client) unlinkCurrentParent(ctx context.Context, owner, repo string, issue issuemanager.Issue, result IssueResult) {
+	details, err := c.GetIssue(ctx, owner, repo, result.Number)
+	if err != nil {
+		logger.Warn("Failed to look up current parent", "issue", issue.Title, "error", err)
+		return
+	}
+	if details.Parent == nil {
+		return
+	}
+
+	if err := c.RemoveParentRelationshipByID(ctx, details.Parent.ID, result.NodeID); err != nil {
+		logger.Warn("Failed to remove parent relationship", "issue", issue.Title, "parent", details.Parent.Title, "error", err)
+		return
+	}
+	c.progressf("Removed parent '%s' (#%d) from issue '%s'\n", details.Parent.Title, details.Parent.Number, issue.Title)
+}
+
+// addToProject adds an issue to its front matter project and applies its project field values.
+func (c *Client) addToProject(ctx context.Context, owner, repo string, issue issuemanager.Issue, number int64) {
+	// Get issue node id by issue number using GraphQL
+	issueNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, number)
+	if err != nil {
+		fmt.Println("Error resolving issue node ID:", err)
+		return
+	}
+
+	// Resolve project name to GraphQL ID
+	logger.Debug("Resolving project name to GraphQL node ID", "project", issue.Project)
+	projectNodeID, err := c.ResolveProjectID(ctx, owner, issue.Project)
+	if err != nil {
+		fmt.Println("Error resolving project ID:", err)
+		return
+	}
+
+	// The file's Status is only applied while the item has none, normally when the issue is first
+	// added. What was applied last isn't recorded, so an existing status can't be told apart from
+	// one moved on the board since, and re-runs must not reset the latter.
+	currentStatus := ""
+	if !c.ForceStatus && hasStatusField(issue.ProjectFields) {
+		if currentStatus, err = c.projectItemStatus(ctx, issueNodeID, projectNodeID); err != nil {
+			logger.Debug("Failed to read current project status", "issue", issue.Title, "error", err)
+		}
+	}
+
+	// Add issue to project
+	itemID, err := c.AddIssueToProject(ctx, issueNodeID, projectNodeID)
+	if err != nil {
+		fmt.Printf("Failed to add issue %s to project '%s': %v\n", issue.Title, issue.Project, err)
+		return
+	}
+
+	// Unknown fields or options only produce warnings so the issue itself still counts as processed
+	for _, field := range issue.ProjectFields {
+		if currentStatus != "" && normalizeName(field.Field) == "status" {
+			if !strings.EqualFold(currentStatus, c.statusOptionName(field.Value)) {
+				logger.Info("Keeping the existing project status; the file's status only applies when the issue is first added (use --force-status to overwrite)", "issue", issue.Title, "status", currentStatus, "file_status", field.Value)
 			}
+			continue
 		}
+		if err := c.SetProjectItemFieldValue(ctx, projectNodeID, itemID, field.Field, field.Value); err != nil {
+			logger.Warn("Failed to set project field", "issue", issue.Title, "project", issue.Project, "field", field.Field, "value", field.Value, "error", err)
+			continue
+		}
+		logger.Info("Set project field", "issue", issue.Title, "field", field.Field, "value", field.Value)
+	}
+}
+
+// progressf prints a progress line about a successful operation unless QuietSuccess is set.
+func (c *Client) progressf(format string, args ...interface{}) {
+	if !c.QuietSuccess {
+		fmt.Printf(format, args...)
 	}
+}
 
-	fmt.Printf("Created %d issues successfully.\n", len(createdIssues))
+// AssignParents (re)establishes the parent relationship of every issue that has both an id and a parent,
+// without touching titles, bodies or labels. It returns the number of issues linked and failed.
+func (c *Client) AssignParents(ctx context.Context, defaultOwner, defaultRepo string, issues []issuemanager.Issue) (linked, failed int) {
+	for _, issue := range issues {
+		if strings.TrimSpace(issue.Id) == "" || strings.TrimSpace(issue.Parent) == "" {
+			continue
+		}
+		owner, repo := issue.Target(defaultOwner, defaultRepo)
+
+		number, err := strconv.ParseInt(strings.TrimSpace(issue.Id), 10, 64)
+		if err != nil {
+			logger.Error("Failed to convert issue ID to int64", "id", issue.Id, "error", err)
+			failed++
+			continue
+		}
+
+		childNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, number)
+		if err != nil {
+			logger.Error("Failed to resolve issue node ID", "issue", issue.Title, "error", err)
+			failed++
+			continue
+		}
+
+		if err := c.UpdateParentRelationship(ctx, owner, repo, childNodeID, issue.Parent); err != nil {
+			logger.Error("Failed to assign parent", "issue", issue.Title, "parent", issue.Parent, "error", err)
+			failed++
+			continue
+		}
+
+		c.progressf("Linked '%s' (#%d) to parent '%s'\n", issue.Title, number, issue.Parent)
+		linked++
+	}
+	return linked, failed
 }
 
 // GetRepositoryInfo retrieves repository information including labels, issue types, and project fields.
@@ -218,6 +702,12 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 	repoQuery := `
 		query($owner: String!, $name: String!) {
 			repository(owner: $owner, name: $name) {
+				isArchived
+				primaryLanguage { name }
+				repositoryTopics(first: 20) {
+					nodes { topic { name } }
+				}
+				licenseInfo { name spdxId }
 				labels(first: 100) {
 					nodes {
 						id
@@ -233,16 +723,24 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 	req.Var("owner", owner)
 	req.Var("name", repo)
 
-	// Get the token and set the Authorization header
-	token, err := c.getToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub token: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-
 	// Define a struct to hold the repository response
 	var repoData struct {
 		Repository struct {
+			IsArchived      bool `json:"isArchived"`
+			PrimaryLanguage *struct {
+				Name string `json:"name"`
+			} `json:"primaryLanguage"`
+			RepositoryTopics struct {
+				Nodes []struct {
+					Topic struct {
+						Name string `json:"name"`
+					} `json:"topic"`
+				} `json:"nodes"`
+			} `json:"repositoryTopics"`
+			LicenseInfo *struct {
+				Name   string `json:"name"`
+				SpdxID string `json:"spdxId"`
+			} `json:"licenseInfo"`
 			Labels struct {
 				Nodes []Label `json:"nodes"`
 			} `json:"labels"`
@@ -250,7 +748,7 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 	}
 
 	// Execute the repository request
-	if err := c.GraphQL.Run(ctx, req, &repoData); err != nil {
+	if err := c.run(ctx, req, &repoData); err != nil {
 		return nil, fmt.Errorf("failed to execute repository GraphQL query: %w", err)
 	}
 
@@ -301,7 +799,6 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 
 	orgReq := graphql.NewRequest(orgQuery)
 	orgReq.Var("owner", owner)
-	orgReq.Header.Set("Authorization", "Bearer "+token)
 
 	var orgData struct {
 		Organization struct {
@@ -318,7 +815,7 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 	}
 
 	// Execute the organization request (don't fail if this doesn't work)
-	if err := c.GraphQL.Run(ctx, orgReq, &orgData); err != nil {
+	if err := c.run(ctx, orgReq, &orgData); err != nil {
 		logger.Debug("Failed to query organization projects (this is normal for personal repositories)", "owner", owner, "error", err)
 		// Don't return error here, just log it and continue with empty project fields
 	} else {
@@ -330,25 +827,107 @@ func (c *Client) GetRepositoryInfo(ctx context.Context, owner, repo string) (*Re
 
 	// Construct the RepositoryInfo
 	repoInfo := &RepositoryInfo{
+		IsArchived:    repoData.Repository.IsArchived,
+		Topics:        []string{},
 		Labels:        repoData.Repository.Labels.Nodes,
 		IssueTypes:    issueTypes,
 		ProjectFields: projectFields,
 	}
+	if language := repoData.Repository.PrimaryLanguage; language != nil {
+		repoInfo.PrimaryLanguage = language.Name
+	}
+	for _, node := range repoData.Repository.RepositoryTopics.Nodes {
+		repoInfo.Topics = append(repoInfo.Topics, node.Topic.Name)
+	}
+	if license := repoData.Repository.LicenseInfo; license != nil {
+		repoInfo.License = license.SpdxID
+		if repoInfo.License == "" || repoInfo.License == "NOASSERTION" {
+			repoInfo.License = license.Name
+		}
+	}
 
 	return repoInfo, nil
 }
 
+// RepositoryStatus holds repository settings that determine whether issues can be created.
+type RepositoryStatus struct {
+	HasIssuesEnabled bool   `json:"hasIssuesEnabled"`
+	IsArchived       bool   `json:"isArchived"`
+	ViewerPermission string `json:"viewerPermission"` // ADMIN, MAINTAIN, WRITE, TRIAGE, READ or empty
+}
+
+// CanCreateIssues reports whether the authenticated user may open issues. Every permission level,
+// including READ, allows opening issues; archived repositories don't accept new issues at all.
+func (s *RepositoryStatus) CanCreateIssues() bool {
+	return !s.IsArchived && s.ViewerPermission != ""
+}
+
+// GetRepositoryStatus retrieves the repository settings checked before creating issues.
+func (c *Client) GetRepositoryStatus(ctx context.Context, owner, repo string) (*RepositoryStatus, error) {
+	req := graphql.NewRequest(`
+		query($owner: String!, $name: String!) {
+			repository(owner: $owner, name: $name) {
+				id
+				hasIssuesEnabled
+				isArchived
+				viewerPermission
+			}
+		}
+	`)
+	req.Var("owner", owner)
+	req.Var("name", repo)
+
+	var out struct {
+		Repository *struct {
+			ID               string `json:"id"`
+			HasIssuesEnabled bool   `json:"hasIssuesEnabled"`
+			IsArchived       bool   `json:"isArchived"`
+			ViewerPermission string `json:"viewerPermission"`
+		} `json:"repository"`
+	}
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("repository query failed: %w", err)
+	}
+	if out.Repository == nil || out.Repository.ID == "" {
+		return nil, fmt.Errorf("repository %s/%s not found or not accessible", owner, repo)
+	}
+
+	return &RepositoryStatus{
+		HasIssuesEnabled: out.Repository.HasIssuesEnabled,
+		IsArchived:       out.Repository.IsArchived,
+		ViewerPermission: out.Repository.ViewerPermission,
+	}, nil
+}
+
+// PreflightCreate checks that issues can be created in the repository, returning an actionable error if not.
+// With force, an archived repository only produces a warning (e.g. when it is about to be unarchived).
+func (c *Client) PreflightCreate(ctx context.Context, owner, repo string, force bool) error {
+	status, err := c.GetRepositoryStatus(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	if !status.HasIssuesEnabled {
+		return fmt.Errorf("issues are disabled for %s/%s; enable them under Settings > General > Features", owner, repo)
+	}
+	if status.IsArchived {
+		if !force {
+			return fmt.Errorf("%s/%s is archived and doesn't accept new issues; unarchive it first (or pass --force to try anyway)", owner, repo)
+		}
+		logger.Warn("Repository is archived; creating issues will fail until it is unarchived", "owner", owner, "repo", repo)
+		status.IsArchived = false
+	}
+	if !status.CanCreateIssues() {
+		return fmt.Errorf("you lack issue-creation permission on %s/%s; check the token's repository access", owner, repo)
+	}
+	return nil
+}
+
 // ResolveIssueNodeID resolves an issue number to its GraphQL node ID using GraphQL.
 func (c *Client) ResolveIssueNodeID(ctx context.Context, owner, repo string, issueNumber int64) (string, error) {
 	if issueNumber <= 0 {
 		return "", fmt.Errorf("invalid issue number: %d", issueNumber)
 	}
 
-	token, err := c.getToken()
-	if err != nil {
-		return "", err
-	}
-
 	// Use GraphQL to get the issue by number
 	req := graphql.NewRequest(`
 		query($owner: String!, $name: String!, $number: Int!) {
@@ -363,7 +942,6 @@ func (c *Client) ResolveIssueNodeID(ctx context.Context, owner, repo string, iss
 	req.Var("owner", owner)
 	req.Var("name", repo)
 	req.Var("number", int(issueNumber))
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var out struct {
 		Repository struct {
@@ -374,7 +952,7 @@ func (c *Client) ResolveIssueNodeID(ctx context.Context, owner, repo string, iss
 		} `json:"repository"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
+	if err := c.run(ctx, req, &out); err != nil {
 		return "", fmt.Errorf("failed to query issue via GraphQL: %w", err)
 	}
 
@@ -385,83 +963,478 @@ func (c *Client) ResolveIssueNodeID(ctx context.Context, owner, repo string, iss
 	return out.Repository.Issue.ID, nil
 }
 
-// ResolveProjectID resolves a project name to its GraphQL node ID.
-func (c *Client) ResolveProjectID(ctx context.Context, owner string, projectName string) (string, error) {
-	token, err := c.getToken()
-	if err != nil {
-		return "", err
-	}
+// IssueDetails holds the current state of an existing GitHub issue.
+type IssueDetails struct {
+	ID        string    `json:"id"`
+	Number    int64     `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	URL       string    `json:"url"`
+	IssueType string    `json:"issueType"`
+	Labels    []string  `json:"labels"`
+	Parent    *IssueRef `json:"parent,omitempty"`
+	Closed    bool      `json:"closed"`
+	ClosedAt  string    `json:"closedAt,omitempty"`
+	CreatedAt string    `json:"createdAt"`
+	UpdatedAt string    `json:"updatedAt"`
+	Author    string    `json:"author"`
+}
 
-	type respPage struct {
-		Organization struct {
-			ProjectsV2 struct {
-				PageInfo struct {
-					HasNextPage bool    `json:"hasNextPage"`
-					EndCursor   *string `json:"endCursor"`
-				} `json:"pageInfo"`
-				Nodes []struct {
-					ID    string `json:"id"`
-					Title string `json:"title"`
-				} `json:"nodes"`
-			} `json:"projectsV2"`
-		} `json:"organization"`
+// IssueRef identifies a related issue, such as a parent.
+type IssueRef struct {
+	ID     string `json:"id"`
+	Number int64  `json:"number"`
+	Title  string `json:"title"`
+}
+
+// GetIssue fetches the current state, type, labels and parent of an issue by number.
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, issueNumber int64) (*IssueDetails, error) {
+	if issueNumber <= 0 {
+		return nil, fmt.Errorf("invalid issue number: %d", issueNumber)
 	}
 
-	var after *string
-	for {
-		req := graphql.NewRequest(`
-			query OrgProjects($login: String!, $first: Int = 50, $after: String) {
-				organization(login: $login) {
-					projectsV2(
-						first: $first
-						after: $after
-						orderBy: { field: UPDATED_AT, direction: DESC }
-					) {
-						pageInfo { hasNextPage endCursor }
-						nodes { id title }
-					}
-				}
-			}
-		`)
-		req.Var("login", owner)
-		req.Var("first", 50)
+	req := graphql.NewRequest(`
+		query($owner: String!, $name: String!, $number: Int!) {
+			repository(owner: $owner, name: $name) {
+				issue(number: $number) {
+					id
+					number
+					title
+					body
+					state
+					url
+					issueType { name }
+					labels(first: 100) {
+						nodes { name }
+					}
+					parent { id number title }
+					closed
+					closedAt
+					createdAt
+					updatedAt
+					author { login }
+				}
+			}
+		}
+	`)
+	req.Var("owner", owner)
+	req.Var("name", repo)
+	req.Var("number", int(issueNumber))
+
+	var out struct {
+		Repository struct {
+			Issue struct {
+				ID        string `json:"id"`
+				Number    int64  `json:"number"`
+				Title     string `json:"title"`
+				Body      string `json:"body"`
+				State     string `json:"state"`
+				URL       string `json:"url"`
+				IssueType *struct {
+					Name string `json:"name"`
+				} `json:"issueType"`
+				Labels struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				Parent    *IssueRef `json:"parent"`
+				Closed    bool      `json:"closed"`
+				ClosedAt  *string   `json:"closedAt"`
+				CreatedAt string    `json:"createdAt"`
+				UpdatedAt string    `json:"updatedAt"`
+				Author    *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("failed to query issue via GraphQL: %w", err)
+	}
+
+	issue := out.Repository.Issue
+	if issue.ID == "" {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", issueNumber, owner, repo)
+	}
+
+	details := &IssueDetails{
+		ID:     issue.ID,
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		State:  issue.State,
+		URL:    issue.URL,
+		Labels: []string{},
+		Parent: issue.Parent,
+
+		Closed:    issue.Closed,
+		CreatedAt: issue.CreatedAt,
+		UpdatedAt: issue.UpdatedAt,
+	}
+	if issue.ClosedAt != nil {
+		details.ClosedAt = *issue.ClosedAt
+	}
+	if issue.Author != nil {
+		details.Author = issue.Author.Login
+	}
+	if issue.IssueType != nil {
+		details.IssueType = issue.IssueType.Name
+	}
+	for _, l := range issue.Labels.Nodes {
+		details.Labels = append(details.Labels, l.Name)
+	}
+	return details, nil
+}
+
+// TransferIssue moves an issue to another repository and returns its new number and URL.
+func (c *Client) TransferIssue(ctx context.Context, issueNodeID, targetRepoID string) (int64, string, error) {
+	req := graphql.NewRequest(`
+		mutation($input: TransferIssueInput!) {
+			transferIssue(input: $input) {
+				issue {
+					id
+					number
+					url
+				}
+			}
+		}
+	`)
+	req.Var("input", map[string]interface{}{
+		"issueId":               issueNodeID,
+		"repositoryId":          targetRepoID,
+		"createLabelsIfMissing": true,
+	})
+
+	var resp struct {
+		TransferIssue struct {
+			Issue struct {
+				ID     string `json:"id"`
+				Number int64  `json:"number"`
+				URL    string `json:"url"`
+			} `json:"issue"`
+		} `json:"transferIssue"`
+	}
+
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return 0, "", fmt.Errorf("transferIssue GraphQL failed: %w", err)
+	}
+	if resp.TransferIssue.Issue.Number == 0 {
+		return 0, "", fmt.Errorf("transferIssue GraphQL returned no issue")
+	}
+	return resp.TransferIssue.Issue.Number, resp.TransferIssue.Issue.URL, nil
+}
+
+// IssueFieldUpdate describes a partial update of an existing issue. Nil fields are left unchanged.
+type IssueFieldUpdate struct {
+	Labels    []string
+	Milestone *string // milestone title
+	State     *string // "open" or "closed"
+	Assignees []string
+}
+
+// UpdateIssueFields applies a partial update (labels, milestone, state, assignees) to an existing issue.
+func (c *Client) UpdateIssueFields(ctx context.Context, owner, repo string, issueNumber int64, update IssueFieldUpdate) error {
+	issueNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, issueNumber)
+	if err != nil {
+		return fmt.Errorf("resolve issue node id: %w", err)
+	}
+
+	input := map[string]interface{}{
+		"id": issueNodeID,
+	}
+
+	if update.Labels != nil {
+		// labelIds replaces every label, so a partial set would silently drop the unresolved ones
+		labelIDs, err := c.resolveLabelIDs(ctx, owner, repo, update.Labels, nil)
+		if err != nil {
+			return err
+		}
+		input["labelIds"] = labelIDs
+	}
+
+	if update.Milestone != nil {
+		milestoneID, err := c.ResolveMilestoneID(ctx, owner, repo, *update.Milestone)
+		if err != nil {
+			return err
+		}
+		input["milestoneId"] = milestoneID
+	}
+
+	if update.State != nil {
+		switch strings.ToLower(strings.TrimSpace(*update.State)) {
+		case "open":
+			input["state"] = "OPEN"
+		case "closed":
+			input["state"] = "CLOSED"
+		default:
+			return fmt.Errorf("invalid state %q (expected open or closed)", *update.State)
+		}
+	}
+
+	if update.Assignees != nil {
+		var assigneeIDs []string
+		for _, login := range update.Assignees {
+			userID, err := c.ResolveUserID(ctx, login)
+			if err != nil {
+				return err
+			}
+			assigneeIDs = append(assigneeIDs, userID)
+		}
+		input["assigneeIds"] = assigneeIDs
+	}
+
+	req := graphql.NewRequest(`
+		mutation($input: UpdateIssueInput!) {
+			updateIssue(input: $input) {
+				issue {
+					id
+					number
+				}
+			}
+		}
+	`)
+	req.Var("input", input)
+
+	var resp struct {
+		UpdateIssue struct {
+			Issue struct {
+				ID     string `json:"id"`
+				Number int64  `json:"number"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return fmt.Errorf("updateIssue GraphQL failed: %w", err)
+	}
+	if resp.UpdateIssue.Issue.ID == "" {
+		return fmt.Errorf("updateIssue GraphQL returned empty issue id")
+	}
+	return nil
+}
+
+// ResolveMilestoneID resolves a milestone title to its GraphQL node ID.
+func (c *Client) ResolveMilestoneID(ctx context.Context, owner, repo, title string) (string, error) {
+	var after *string
+	for {
+		req := graphql.NewRequest(`
+			query($owner: String!, $name: String!, $after: String) {
+				repository(owner: $owner, name: $name) {
+					milestones(first: 100, after: $after) {
+						pageInfo { hasNextPage endCursor }
+						nodes { id title }
+					}
+				}
+			}
+		`)
+		req.Var("owner", owner)
+		req.Var("name", repo)
 		req.Var("after", after)
-		req.Header.Set("Authorization", "Bearer "+token)
 
-		var out respPage
-		if err := c.GraphQL.Run(ctx, req, &out); err != nil {
-			return "", fmt.Errorf("failed to query GitHub GraphQL API: %w", err)
+		var out struct {
+			Repository struct {
+				Milestones struct {
+					PageInfo struct {
+						HasNextPage bool    `json:"hasNextPage"`
+						EndCursor   *string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID    string `json:"id"`
+						Title string `json:"title"`
+					} `json:"nodes"`
+				} `json:"milestones"`
+			} `json:"repository"`
+		}
+		if err := c.run(ctx, req, &out); err != nil {
+			return "", fmt.Errorf("milestones query failed: %w", err)
 		}
 
-		for _, n := range out.Organization.ProjectsV2.Nodes {
-			if strings.EqualFold(strings.TrimSpace(n.Title), strings.TrimSpace(projectName)) {
+		for _, n := range out.Repository.Milestones.Nodes {
+			if strings.EqualFold(strings.TrimSpace(n.Title), strings.TrimSpace(title)) {
 				return n.ID, nil
 			}
 		}
 
-		if !out.Organization.ProjectsV2.PageInfo.HasNextPage || out.Organization.ProjectsV2.PageInfo.EndCursor == nil {
+		if !out.Repository.Milestones.PageInfo.HasNextPage || out.Repository.Milestones.PageInfo.EndCursor == nil {
 			break
 		}
-		after = out.Organization.ProjectsV2.PageInfo.EndCursor
+		after = out.Repository.Milestones.PageInfo.EndCursor
 	}
 
-	return "", fmt.Errorf("project with name %q not found", projectName)
+	return "", fmt.Errorf("milestone %q not found in %s/%s", title, owner, repo)
 }
 
-// NewClient creates a new GitHub client with GraphQL support.
-func NewClient(ctx context.Context, pat string) *Client {
-	return &Client{
-		GraphQL: graphql.NewClient("https://api.github.com/graphql"),
+// ResolveUserID resolves a user login to its GraphQL node ID.
+func (c *Client) ResolveUserID(ctx context.Context, login string) (string, error) {
+	req := graphql.NewRequest(`
+		query($login: String!) {
+			user(login: $login) { id }
+		}
+	`)
+	req.Var("login", strings.TrimPrefix(strings.TrimSpace(login), "@"))
+
+	var out struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
 	}
+	if err := c.run(ctx, req, &out); err != nil {
+		return "", fmt.Errorf("user query failed for %q: %w", login, err)
+	}
+	if out.User.ID == "" {
+		return "", fmt.Errorf("user %q not found", login)
+	}
+	return out.User.ID, nil
 }
 
-// AddIssueToProject adds an issue to a GitHub project using GraphQL.
-func (c *Client) AddIssueToProject(ctx context.Context, issueNodeID, projectNodeID string) error {
-	token, err := c.getToken()
+// ResolveProjectID resolves a project name to its GraphQL node ID. owner may be an organization or
+// a user.
+func (c *Client) ResolveProjectID(ctx context.Context, owner string, projectName string) (string, error) {
+	id, err := c.findProjectID(ctx, owner, projectName)
 	if err != nil {
-		return err
+		return "", err
 	}
+	if id == "" {
+		return "", fmt.Errorf("project with name %q not found", projectName)
+	}
+	return id, nil
+}
+
+// findProjectID returns the node ID of owner's project titled projectName (ignoring case), or ""
+// when owner has no such project.
+func (c *Client) findProjectID(ctx context.Context, owner string, projectName string) (string, error) {
+	if id, ok := c.cache.projectID(owner, projectName); ok {
+		return id, nil
+	}
+
+	var id string
+	err := c.eachProject(ctx, owner, func(project Project) bool {
+		if strings.EqualFold(strings.TrimSpace(project.Title), strings.TrimSpace(projectName)) {
+			id = project.ID
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	if id != "" {
+		c.cache.setProjectID(owner, projectName, id)
+	}
+	return id, nil
+}
+
+// Project is a GitHub project (v2) owned by an organization or user.
+type Project struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Public bool   `json:"public"`
+}
+
+// ListProjects returns every project (v2) of an organization or user, most recently updated first.
+func (c *Client) ListProjects(ctx context.Context, owner string) ([]Project, error) {
+	var projects []Project
+	err := c.eachProject(ctx, owner, func(project Project) bool {
+		projects = append(projects, project)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
 
+// eachProject calls fn with every project (v2) of an organization or user, most recently updated
+// first, until fn returns false. Pages are only fetched while fn asks for more.
+func (c *Client) eachProject(ctx context.Context, owner string, fn func(Project) bool) error {
+	var after *string
+	pageSize := 50
+	for {
+		// repositoryOwner covers both organizations and users, which organization(login:) doesn't
+		req := graphql.NewRequest(`
+			query OwnerProjects($login: String!, $first: Int = 50, $after: String) {
+				repositoryOwner(login: $login) {
+					... on ProjectV2Owner {
+						projectsV2(
+							first: $first
+							after: $after
+							orderBy: { field: UPDATED_AT, direction: DESC }
+						) {
+							pageInfo { hasNextPage endCursor }
+							nodes { id number title url public }
+						}
+					}
+				}
+			}
+		`)
+		req.Var("login", owner)
+		req.Var("after", after)
+
+		var out struct {
+			RepositoryOwner *struct {
+				ProjectsV2 struct {
+					PageInfo struct {
+						HasNextPage bool    `json:"hasNextPage"`
+						EndCursor   *string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []Project `json:"nodes"`
+				} `json:"projectsV2"`
+			} `json:"repositoryOwner"`
+		}
+		if err := c.runPage(ctx, req, &pageSize, &out); err != nil {
+			return fmt.Errorf("failed to query GitHub GraphQL API: %w", err)
+		}
+		if out.RepositoryOwner == nil {
+			return fmt.Errorf("organization or user %q not found", owner)
+		}
+
+		for _, project := range out.RepositoryOwner.ProjectsV2.Nodes {
+			if !fn(project) {
+				return nil
+			}
+		}
+
+		pageInfo := out.RepositoryOwner.ProjectsV2.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			return nil
+		}
+		after = pageInfo.EndCursor
+	}
+}
+
+// DefaultGraphQLEndpoint is the GitHub.com GraphQL API.
+const DefaultGraphQLEndpoint = "https://api.github.com/graphql"
+
+// GraphQLEndpoint is the endpoint clients created by NewClient talk to. Override it to route calls
+// through a proxy or to a mock server.
+var GraphQLEndpoint = DefaultGraphQLEndpoint
+
+// NewClient creates a new GitHub client with GraphQL support. Every request it sends carries pat as
+// a bearer token; an empty pat is looked up with getToken on each request instead.
+func NewClient(ctx context.Context, pat string) *Client {
+	base := http.DefaultClient
+	if httpClient != nil {
+		base = httpClient
+	}
+	authenticated := &http.Client{
+		Transport: &authTransport{token: pat, base: base.Transport},
+		Timeout:   base.Timeout,
+	}
+	return &Client{
+		GraphQL: graphql.NewClient(GraphQLEndpoint, graphql.WithHTTPClient(authenticated)),
+		cache:   newResolveCache(),
+		retries: &retryBudget{limit: MaxRetriesTotal},
+	}
+}
+
+// AddIssueToProject adds an issue to a GitHub project using GraphQL and returns the project item ID.
+// Adding an issue that is already in the project returns its existing item.
+func (c *Client) AddIssueToProject(ctx context.Context, issueNodeID, projectNodeID string) (string, error) {
 	req := graphql.NewRequest(`
 		mutation($issueID: ID!, $projectID: ID!) {
 		  addProjectV2ItemById(input: { projectId: $projectID, contentId: $issueID }) {
@@ -471,7 +1444,6 @@ func (c *Client) AddIssueToProject(ctx context.Context, issueNodeID, projectNode
 	`)
 	req.Var("issueID", issueNodeID)
 	req.Var("projectID", projectNodeID)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		AddProjectV2ItemById struct {
@@ -481,26 +1453,324 @@ func (c *Client) AddIssueToProject(ctx context.Context, issueNodeID, projectNode
 		} `json:"addProjectV2ItemById"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		if strings.Contains(err.Error(), "content already exists in the project") {
-			return nil
+			return c.findProjectItemID(ctx, issueNodeID, projectNodeID)
+		}
+		return "", fmt.Errorf("failed to add issue to project via GraphQL: %w", err)
+	}
+
+	if resp.AddProjectV2ItemById.Item.ID == "" {
+		return "", fmt.Errorf("GraphQL succeeded but returned empty item id")
+	}
+	return resp.AddProjectV2ItemById.Item.ID, nil
+}
+
+// findProjectItemID returns the ID of the item representing an issue in a project.
+func (c *Client) findProjectItemID(ctx context.Context, issueNodeID, projectNodeID string) (string, error) {
+	req := graphql.NewRequest(`
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					projectItems(first: 100) {
+						nodes {
+							id
+							project { id }
+						}
+					}
+				}
+			}
+		}
+	`)
+	req.Var("id", issueNodeID)
+
+	var out struct {
+		Node struct {
+			ProjectItems struct {
+				Nodes []struct {
+					ID      string `json:"id"`
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"nodes"`
+			} `json:"projectItems"`
+		} `json:"node"`
+	}
+
+	if err := c.run(ctx, req, &out); err != nil {
+		return "", fmt.Errorf("failed to query project items: %w", err)
+	}
+	for _, item := range out.Node.ProjectItems.Nodes {
+		if item.Project.ID == projectNodeID {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("issue is not an item of project %s", projectNodeID)
+}
+
+// projectFieldDefinition describes a project field and, for single-select fields, its options.
+type projectFieldDefinition struct {
+	ID       string
+	Name     string
+	DataType string
+	Options  map[string]string // normalized option name (or iteration title) -> option (iteration) ID
+}
+
+// projectIteration is an iteration of a project iteration field.
+type projectIteration struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// resolveProjectField looks up a project field by name, caching the result for the run.
+func (c *Client) resolveProjectField(ctx context.Context, projectNodeID, fieldName string) (*projectFieldDefinition, error) {
+	if field, ok := c.cache.projectField(projectNodeID, fieldName); ok {
+		return field, nil
+	}
+
+	req := graphql.NewRequest(`
+		query($id: ID!, $name: String!) {
+			node(id: $id) {
+				... on ProjectV2 {
+					field(name: $name) {
+						... on ProjectV2FieldCommon {
+							id
+							name
+							dataType
+						}
+						... on ProjectV2SingleSelectField {
+							options { id name }
+						}
+						... on ProjectV2IterationField {
+							configuration {
+								iterations { id title }
+								completedIterations { id title }
+							}
+						}
+					}
+				}
+			}
 		}
-		return fmt.Errorf("failed to add issue to project via GraphQL: %w", err)
+	`)
+	req.Var("id", projectNodeID)
+	req.Var("name", fieldName)
+
+	var out struct {
+		Node struct {
+			Field *struct {
+				ID       string `json:"id"`
+				Name     string `json:"name"`
+				DataType string `json:"dataType"`
+				Options  []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"options"`
+				Configuration struct {
+					Iterations          []projectIteration `json:"iterations"`
+					CompletedIterations []projectIteration `json:"completedIterations"`
+				} `json:"configuration"`
+			} `json:"field"`
+		} `json:"node"`
+	}
+
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("project field query failed: %w", err)
+	}
+	if out.Node.Field == nil || out.Node.Field.ID == "" {
+		return nil, fmt.Errorf("project field %q not found", fieldName)
+	}
+
+	field := &projectFieldDefinition{
+		ID:       out.Node.Field.ID,
+		Name:     out.Node.Field.Name,
+		DataType: out.Node.Field.DataType,
+		Options:  make(map[string]string),
+	}
+	for _, option := range out.Node.Field.Options {
+		field.Options[normalizeName(option.Name)] = option.ID
+	}
+	for _, iteration := range append(out.Node.Field.Configuration.CompletedIterations, out.Node.Field.Configuration.Iterations...) {
+		field.Options[normalizeName(iteration.Title)] = iteration.ID
+	}
+	c.cache.setProjectField(projectNodeID, fieldName, field)
+	return field, nil
+}
+
+// SetProjectItemFieldValue sets a field of a project item. The value is an option name for
+// single-select fields, an iteration title for iteration fields, an ISO date (YYYY-MM-DD) for date
+// fields, or text or a number; values that don't fit the field's kind are rejected.
+func (c *Client) SetProjectItemFieldValue(ctx context.Context, projectNodeID, itemID, fieldName, value string) error {
+	field, err := c.resolveProjectField(ctx, projectNodeID, fieldName)
+	if err != nil {
+		return err
+	}
+	fieldValue, err := c.projectFieldValue(field, value)
+	if err != nil {
+		return err
+	}
+	return c.updateProjectItemField(ctx, projectNodeID, itemID, field.ID, fieldValue)
+}
+
+// projectFieldValue converts a front matter value into the value shape of the project field's kind.
+func (c *Client) projectFieldValue(field *projectFieldDefinition, value string) (map[string]interface{}, error) {
+	switch field.DataType {
+	case "SINGLE_SELECT":
+		optionID, err := c.projectOptionID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"singleSelectOptionId": optionID}, nil
+	case "ITERATION":
+		iterationID, ok := field.Options[normalizeName(value)]
+		if !ok {
+			return nil, fmt.Errorf("iteration %q not found in project field %q", value, field.Name)
+		}
+		return map[string]interface{}{"iterationId": iterationID}, nil
+	case "DATE":
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("project field %q is a date field; %q is not a YYYY-MM-DD date", field.Name, value)
+		}
+		return map[string]interface{}{"date": date.Format("2006-01-02")}, nil
+	case "NUMBER":
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("project field %q is a number field; %q is not a number", field.Name, value)
+		}
+		return map[string]interface{}{"number": number}, nil
+	case "TEXT":
+		return map[string]interface{}{"text": value}, nil
+	default:
+		return nil, fmt.Errorf("project field %q has unsupported type %s", field.Name, field.DataType)
+	}
+}
+
+// projectOptionID resolves an option name of a single-select field to its ID. Status field values
+// are translated through the status aliases first; unmapped values pass through.
+func (c *Client) projectOptionID(field *projectFieldDefinition, optionName string) (string, error) {
+	if field.DataType != "SINGLE_SELECT" {
+		return "", fmt.Errorf("project field %q is not a single-select field", field.Name)
+	}
+
+	if normalizeName(field.Name) == "status" {
+		if alias := c.statusOptionName(optionName); alias != strings.TrimSpace(optionName) {
+			logger.Debug("Mapping status alias", "status", optionName, "alias", alias)
+			optionName = alias
+		}
+	}
+
+	optionID, ok := field.Options[normalizeName(optionName)]
+	if !ok {
+		return "", fmt.Errorf("option %q not found in project field %q", optionName, field.Name)
+	}
+	return optionID, nil
+}
+
+// statusOptionName returns the Status option a local status value maps to through the status aliases.
+func (c *Client) statusOptionName(value string) string {
+	if alias, ok := c.statusAliases[normalizeName(value)]; ok {
+		return alias
+	}
+	return strings.TrimSpace(value)
+}
+
+// hasStatusField reports whether the project field assignments include the Status field.
+func hasStatusField(fields []issuemanager.ProjectFieldValue) bool {
+	for _, field := range fields {
+		if normalizeName(field.Field) == "status" {
+			return true
+		}
+	}
+	return false
+}
+
+// projectItemStatus returns the Status of an issue's item in a project, or "" if the issue isn't
+// in the project yet or has no status.
+func (c *Client) projectItemStatus(ctx context.Context, issueNodeID, projectNodeID string) (string, error) {
+	req := graphql.NewRequest(`
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					projectItems(first: 100) {
+						nodes {
+							project { id }
+							fieldValueByName(name: "Status") {
+								... on ProjectV2ItemFieldSingleSelectValue { name }
+							}
+						}
+					}
+				}
+			}
+		}
+	`)
+	req.Var("id", issueNodeID)
+
+	var out struct {
+		Node struct {
+			ProjectItems struct {
+				Nodes []struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+					FieldValueByName *struct {
+						Name string `json:"name"`
+					} `json:"fieldValueByName"`
+				} `json:"nodes"`
+			} `json:"projectItems"`
+		} `json:"node"`
+	}
+
+	if err := c.run(ctx, req, &out); err != nil {
+		return "", fmt.Errorf("failed to query project item status: %w", err)
+	}
+	for _, item := range out.Node.ProjectItems.Nodes {
+		if item.Project.ID == projectNodeID && item.FieldValueByName != nil {
+			return item.FieldValueByName.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// SetStatusAliases maps local status values to the option names of projects' Status field.
+func (c *Client) SetStatusAliases(aliases map[string]string) {
+	c.statusAliases = make(map[string]string, len(aliases))
+	for from, to := range aliases {
+		c.statusAliases[normalizeName(from)] = strings.TrimSpace(to)
+	}
+}
+
+// updateProjectItemField sets a project item field to value, which must match the field's value shape.
+func (c *Client) updateProjectItemField(ctx context.Context, projectNodeID, itemID, fieldID string, value map[string]interface{}) error {
+	req := graphql.NewRequest(`
+		mutation($input: UpdateProjectV2ItemFieldValueInput!) {
+			updateProjectV2ItemFieldValue(input: $input) {
+				projectV2Item { id }
+			}
+		}
+	`)
+	req.Var("input", map[string]interface{}{
+		"projectId": projectNodeID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+		"value":     value,
+	})
+
+	var resp struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID string `json:"id"`
+			} `json:"projectV2Item"`
+		} `json:"updateProjectV2ItemFieldValue"`
 	}
 
-	if resp.AddProjectV2ItemById.Item.ID == "" {
-		return fmt.Errorf("GraphQL succeeded but returned empty item id")
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return fmt.Errorf("updateProjectV2ItemFieldValue GraphQL failed: %w", err)
 	}
 	return nil
 }
 
 // CreateIssue creates a GitHub issue using GraphQL with proper parent relationship.
 func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue issuemanager.Issue) IssueResult {
-	token, err := c.getToken()
-	if err != nil {
-		return IssueResult{Err: err}
-	}
-
 	// Get repository node ID
 	repoID, err := c.ResolveRepositoryID(ctx, owner, repo)
 	if err != nil {
@@ -514,6 +1784,7 @@ func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue issu
 				issue {
 					id
 					number
+					url
 					title
 				}
 			}
@@ -539,23 +1810,31 @@ func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue issu
 
 	// Add labels if they exist
 	if len(issue.Labels) > 0 {
-		input["labelIds"] = c.resolveLabelIDs(ctx, owner, repo, issue.Labels)
+		labelIDs, err := c.resolveLabelIDs(ctx, owner, repo, issue.Labels, issue.LabelDefinitions)
+		if err != nil {
+			logger.Warn("Creating issue without some labels", "issue", issue.Title, "error", err)
+		}
+		input["labelIds"] = labelIDs
+	}
+
+	if ids := c.resolveAssigneeIDs(ctx, issue.Assignees); len(ids) > 0 {
+		input["assigneeIds"] = ids
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		CreateIssue struct {
 			Issue struct {
 				ID     string `json:"id"`
 				Number int64  `json:"number"`
+				URL    string `json:"url"`
 				Title  string `json:"title"`
 			} `json:"issue"`
 		} `json:"createIssue"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		return IssueResult{Err: fmt.Errorf("createIssue GraphQL failed: %w", err)}
 	}
 
@@ -565,6 +1844,7 @@ func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue issu
 
 	return IssueResult{
 		Number: resp.CreateIssue.Issue.Number,
+		URL:    resp.CreateIssue.Issue.URL,
 		NodeID: resp.CreateIssue.Issue.ID,
 		Err:    nil,
 	}
@@ -572,11 +1852,6 @@ func (c *Client) CreateIssue(ctx context.Context, owner, repo string, issue issu
 
 // UpdateIssue updates an existing GitHub issue using GraphQL with proper parent relationship.
 func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, issue issuemanager.Issue, issueNumber int64) IssueResult {
-	token, err := c.getToken()
-	if err != nil {
-		return IssueResult{Err: err}
-	}
-
 	// Get the issue node ID first
 	issueNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, issueNumber)
 	if err != nil {
@@ -590,6 +1865,7 @@ func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, issue issu
 				issue {
 					id
 					number
+					url
 					title
 					body
 				}
@@ -613,26 +1889,28 @@ func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, issue issu
 		}
 	}
 
-	// Add labels if they exist
-	if len(issue.Labels) > 0 {
-		input["labelIds"] = c.resolveLabelIDs(ctx, owner, repo, issue.Labels)
+	// Labels are reconciled separately below; labelIds here would replace the whole set
+
+	// An empty assigneeIds would unassign everyone when none of the logins resolve
+	if ids := c.resolveAssigneeIDs(ctx, issue.Assignees); len(ids) > 0 {
+		input["assigneeIds"] = ids
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		UpdateIssue struct {
 			Issue struct {
 				ID     string `json:"id"`
 				Number int64  `json:"number"`
+				URL    string `json:"url"`
 				Title  string `json:"title"`
 				Body   string `json:"body"`
 			} `json:"issue"`
 		} `json:"updateIssue"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		return IssueResult{Err: fmt.Errorf("updateIssue GraphQL failed: %w", err)}
 	}
 
@@ -640,8 +1918,13 @@ func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, issue issu
 		return IssueResult{Err: fmt.Errorf("updateIssue GraphQL returned empty issue id")}
 	}
 
+	if err := c.reconcileLabels(ctx, owner, repo, issueNodeID, issue); err != nil {
+		logger.Warn("Failed to update labels", "issue", issue.Title, "error", err)
+	}
+
 	return IssueResult{
 		Number: resp.UpdateIssue.Issue.Number,
+		URL:    resp.UpdateIssue.Issue.URL,
 		NodeID: resp.UpdateIssue.Issue.ID,
 		Err:    nil,
 	}
@@ -649,11 +1932,6 @@ func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, issue issu
 
 // --- NEW: GraphQL path to create an issue with an Issue Type and proper parent relationship
 func (c *Client) CreateIssueWithTypeGraphQL(ctx context.Context, owner, repo string, issue issuemanager.Issue) IssueResult {
-	token, err := c.getToken()
-	if err != nil {
-		return IssueResult{Err: err}
-	}
-
 	// 1) Resolve repository node ID
 	repoID, err := c.ResolveRepositoryID(ctx, owner, repo)
 	if err != nil {
@@ -673,6 +1951,7 @@ func (c *Client) CreateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 				issue {
 					id
 					number
+					url
 					node_id: id
 					title
 					issueType { id name }
@@ -701,17 +1980,25 @@ func (c *Client) CreateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 
 	// Add labels if they exist
 	if len(issue.Labels) > 0 {
-		input["labelIds"] = c.resolveLabelIDs(ctx, owner, repo, issue.Labels)
+		labelIDs, err := c.resolveLabelIDs(ctx, owner, repo, issue.Labels, issue.LabelDefinitions)
+		if err != nil {
+			logger.Warn("Creating issue without some labels", "issue", issue.Title, "error", err)
+		}
+		input["labelIds"] = labelIDs
+	}
+
+	if ids := c.resolveAssigneeIDs(ctx, issue.Assignees); len(ids) > 0 {
+		input["assigneeIds"] = ids
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		CreateIssue struct {
 			Issue struct {
 				ID        string `json:"id"`
 				Number    int64  `json:"number"`
+				URL       string `json:"url"`
 				Title     string `json:"title"`
 				IssueType struct {
 					ID   string `json:"id"`
@@ -720,7 +2007,7 @@ func (c *Client) CreateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 			} `json:"issue"`
 		} `json:"createIssue"`
 	}
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		return IssueResult{Err: fmt.Errorf("createIssue GraphQL failed: %w", err)}
 	}
 	if resp.CreateIssue.Issue.ID == "" {
@@ -729,18 +2016,15 @@ func (c *Client) CreateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 
 	return IssueResult{
 		Number: resp.CreateIssue.Issue.Number,
+		URL:    resp.CreateIssue.Issue.URL,
 		NodeID: resp.CreateIssue.Issue.ID,
 		Err:    nil,
 	}
 }
 
 // UpdateIssueWithTypeGraphQL updates an existing GitHub issue with issue type using GraphQL and proper parent relationship.
+// The type is set whether or not the issue had one; an issue without a type has its type cleared.
 func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo string, issue issuemanager.Issue, issueNumber int64) IssueResult {
-	token, err := c.getToken()
-	if err != nil {
-		return IssueResult{Err: err}
-	}
-
 	// Get the issue node ID first
 	issueNodeID, err := c.ResolveIssueNodeID(ctx, owner, repo, issueNumber)
 	if err != nil {
@@ -763,6 +2047,7 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 				issue {
 					id
 					number
+					url
 					title
 					body
 					issueType { id name }
@@ -778,9 +2063,11 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 		"body":  issue.Body,
 	}
 
-	// Add issue type if provided
+	// Set the issue type, or clear it (null) when the issue no longer has one
 	if typeID != "" {
 		input["issueTypeId"] = typeID
+	} else {
+		input["issueTypeId"] = nil
 	}
 
 	// Handle parent relationship update using addSubIssue mutation
@@ -792,19 +2079,21 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 		}
 	}
 
-	// Add labels if they exist
-	if len(issue.Labels) > 0 {
-		input["labelIds"] = c.resolveLabelIDs(ctx, owner, repo, issue.Labels)
+	// Labels are reconciled separately below; labelIds here would replace the whole set
+
+	// An empty assigneeIds would unassign everyone when none of the logins resolve
+	if ids := c.resolveAssigneeIDs(ctx, issue.Assignees); len(ids) > 0 {
+		input["assigneeIds"] = ids
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		UpdateIssue struct {
 			Issue struct {
 				ID        string `json:"id"`
 				Number    int64  `json:"number"`
+				URL       string `json:"url"`
 				Title     string `json:"title"`
 				Body      string `json:"body"`
 				IssueType struct {
@@ -815,7 +2104,7 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 		} `json:"updateIssue"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		return IssueResult{Err: fmt.Errorf("updateIssue GraphQL failed: %w", err)}
 	}
 
@@ -823,8 +2112,13 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 		return IssueResult{Err: fmt.Errorf("updateIssue GraphQL returned empty issue id")}
 	}
 
+	if err := c.reconcileLabels(ctx, owner, repo, issueNodeID, issue); err != nil {
+		logger.Warn("Failed to update labels", "issue", issue.Title, "error", err)
+	}
+
 	return IssueResult{
 		Number: resp.UpdateIssue.Issue.Number,
+		URL:    resp.UpdateIssue.Issue.URL,
 		NodeID: resp.UpdateIssue.Issue.ID,
 		Err:    nil,
 	}
@@ -832,9 +2126,8 @@ func (c *Client) UpdateIssueWithTypeGraphQL(ctx context.Context, owner, repo str
 
 // --- NEW: Resolve repository node ID (needed by createIssue)
 func (c *Client) ResolveRepositoryID(ctx context.Context, owner, repo string) (string, error) {
-	token, err := c.getToken()
-	if err != nil {
-		return "", err
+	if id, ok := c.cache.repoID(owner, repo); ok {
+		return id, nil
 	}
 
 	req := graphql.NewRequest(`
@@ -844,30 +2137,68 @@ func (c *Client) ResolveRepositoryID(ctx context.Context, owner, repo string) (s
 	`)
 	req.Var("owner", owner)
 	req.Var("name", repo)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var out struct {
 		Repository struct {
 			ID string `json:"id"`
 		} `json:"repository"`
 	}
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
+	if err := c.run(ctx, req, &out); err != nil {
 		return "", fmt.Errorf("repository query failed: %w", err)
 	}
 	if out.Repository.ID == "" {
 		return "", fmt.Errorf("repository id empty for %s/%s", owner, repo)
 	}
+	c.cache.setRepoID(owner, repo, out.Repository.ID)
 	return out.Repository.ID, nil
 }
 
 // --- NEW: Resolve Issue Type ID by name (e.g., "Bug", "Task") for a repo
 func (c *Client) ResolveIssueTypeID(ctx context.Context, owner, repo, typeName string) (string, error) {
-	token, err := c.getToken()
+	if strings.TrimSpace(typeName) == "" {
+		return "", fmt.Errorf("issue type name is empty")
+	}
+
+	alias, aliased := c.typeAliases[normalizeName(typeName)]
+	lookup := typeName
+	if aliased {
+		lookup = alias
+	}
+	types, err := c.repoIssueTypes(ctx, owner, repo, lookup)
 	if err != nil {
 		return "", err
 	}
-	if strings.TrimSpace(typeName) == "" {
-		return "", fmt.Errorf("issue type name is empty")
+	if aliased {
+		logger.Debug("Mapping issue type alias", "type", typeName, "alias", alias)
+		if id, ok := types[normalizeName(alias)]; ok {
+			return id, nil
+		}
+		return "", fmt.Errorf("issue type %q (alias of %q) not found/enabled in %s/%s", alias, typeName, owner, repo)
+	}
+	if id, ok := types[normalizeName(typeName)]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("issue type %q not found/enabled in %s/%s", typeName, owner, repo)
+}
+
+// SetTypeAliases maps local issue type names to the repository's type names for ResolveIssueTypeID.
+// Names that aren't aliased resolve directly.
+func (c *Client) SetTypeAliases(aliases map[string]string) {
+	c.typeAliases = make(map[string]string, len(aliases))
+	for from, to := range aliases {
+		c.typeAliases[normalizeName(from)] = strings.TrimSpace(to)
+	}
+}
+
+// repoIssueTypes returns the repository's issue types keyed by normalized name, fetching them on
+// first use. Types loaded from the disk cache are fetched again once if any of names is missing
+// from them, in case the type was added since they were cached.
+func (c *Client) repoIssueTypes(ctx context.Context, owner, repo string, names ...string) (map[string]string, error) {
+	if types, ok := c.cache.issueTypes(owner, repo); ok {
+		if !missingName(types, names) || !c.cache.dropDiskEntry(diskCacheTypes, owner, repo) {
+			return types, nil
+		}
+		logger.Debug("Issue type missing from the disk cache, fetching the repository's types again", "owner", owner, "repo", repo)
 	}
 
 	req := graphql.NewRequest(`
@@ -881,7 +2212,6 @@ func (c *Client) ResolveIssueTypeID(ctx context.Context, owner, repo, typeName s
 	`)
 	req.Var("owner", owner)
 	req.Var("name", repo)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var out struct {
 		Repository struct {
@@ -893,150 +2223,389 @@ func (c *Client) ResolveIssueTypeID(ctx context.Context, owner, repo, typeName s
 			} `json:"issueTypes"`
 		} `json:"repository"`
 	}
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
-		return "", fmt.Errorf("issueTypes query failed: %w", err)
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("issueTypes query failed: %w", err)
 	}
+
+	types := make(map[string]string)
 	for _, n := range out.Repository.IssueTypes.Nodes {
-		if strings.EqualFold(strings.TrimSpace(n.Name), strings.TrimSpace(typeName)) {
-			return n.ID, nil
+		types[normalizeName(n.Name)] = n.ID
+	}
+	c.cache.setIssueTypes(owner, repo, types)
+	return types, nil
+}
+
+// resolveAssigneeIDs converts user logins to their GraphQL node IDs. Logins that can't be resolved
+// are skipped with a warning so the issue itself is still created or updated.
+func (c *Client) resolveAssigneeIDs(ctx context.Context, logins []string) []string {
+	ids := []string{}
+	for _, login := range logins {
+		userID, err := c.ResolveUserID(ctx, login)
+		if err != nil {
+			logger.Warn("Skipping unknown assignee", "assignee", login, "error", err)
+			continue
 		}
+		ids = append(ids, userID)
 	}
-	return "", fmt.Errorf("issue type %q not found/enabled in %s/%s", typeName, owner, repo)
+	return ids
 }
 
-// resolveLabelIDs converts label names to their GraphQL node IDs.
-func (c *Client) resolveLabelIDs(ctx context.Context, owner, repo string, labelNames []string) []string {
+// resolveLabelIDs converts label names to their GraphQL node IDs. Missing labels created because
+// of CreateMissingLabels take their color and description from definitions when listed there.
+// IDs are returned in the order of labelNames, so labels are applied in front matter order; names
+// that resolve to the same label (e.g. differing only in case) are applied once. The IDs that did
+// resolve are returned even when some labels could not be found or created; the error then names
+// the ones that are missing.
+func (c *Client) resolveLabelIDs(ctx context.Context, owner, repo string, labelNames []string, definitions []issuemanager.LabelDefinition) ([]string, error) {
 	if len(labelNames) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	token, err := c.getToken()
+	labels, err := c.repoLabels(ctx, owner, repo, labelNames...)
 	if err != nil {
-		logger.Error("Failed to get token for label resolution", "error", err)
-		return nil
+		return nil, fmt.Errorf("failed to resolve label IDs: %w", err)
 	}
 
-	req := graphql.NewRequest(`
-		query($owner: String!, $name: String!, $first: Int!) {
-			repository(owner: $owner, name: $name) {
-				labels(first: $first) {
-					nodes {
-						id
-						name
-					}
-				}
+	var labelIDs, unresolved []string
+	seen := make(map[string]bool, len(labelNames))
+	for _, labelName := range labelNames {
+		if seen[normalizeName(labelName)] {
+			continue
+		}
+		seen[normalizeName(labelName)] = true
+
+		if id, ok := labels[normalizeName(labelName)]; ok {
+			labelIDs = append(labelIDs, id)
+			continue
+		}
+
+		if !c.CreateMissingLabels {
+			unresolved = append(unresolved, labelName)
+			continue
+		}
+
+		color, description := DefaultLabelColor, ""
+		for _, definition := range definitions {
+			if normalizeName(definition.Name) != normalizeName(labelName) {
+				continue
 			}
+			if definition.Color != "" {
+				color = definition.Color
+			}
+			description = definition.Description
 		}
-	`)
-	req.Var("owner", owner)
-	req.Var("name", repo)
-	req.Var("first", 100) // Should be enough for most repos
-	req.Header.Set("Authorization", "Bearer "+token)
 
-	var out struct {
-		Repository struct {
-			Labels struct {
-				Nodes []struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-				} `json:"nodes"`
-			} `json:"labels"`
-		} `json:"repository"`
+		labelID, err := c.CreateLabel(ctx, owner, repo, strings.TrimSpace(labelName), color, description)
+		if err != nil {
+			logger.Warn("Failed to create missing label", "label", labelName, "error", err)
+			unresolved = append(unresolved, labelName)
+			continue
+		}
+		logger.Info("Created missing label", "label", labelName, "owner", owner, "repo", repo)
+		labelIDs = append(labelIDs, labelID)
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
-		logger.Error("Failed to resolve label IDs", "error", err)
-		return nil
+	if len(unresolved) > 0 {
+		return labelIDs, fmt.Errorf("labels not found in %s/%s: %s", owner, repo, strings.Join(unresolved, ", "))
 	}
+	return labelIDs, nil
+}
 
-	var labelIDs []string
-	for _, labelName := range labelNames {
-		for _, label := range out.Repository.Labels.Nodes {
-			if strings.EqualFold(strings.TrimSpace(label.Name), strings.TrimSpace(labelName)) {
-				labelIDs = append(labelIDs, label.ID)
-				break
-			}
+// repoLabels returns the repository's labels keyed by normalized name, fetching them on first use.
+// All pages are fetched because the result is cached for later lookups of other names. Labels
+// loaded from the disk cache are fetched again once if any of names is missing from them, in case
+// the label was created since they were cached.
+func (c *Client) repoLabels(ctx context.Context, owner, repo string, names ...string) (map[string]string, error) {
+	if labels, ok := c.cache.labels(owner, repo); ok {
+		if !missingName(labels, names) || !c.cache.dropDiskEntry(diskCacheLabels, owner, repo) {
+			return labels, nil
 		}
+		logger.Debug("Label missing from the disk cache, fetching the repository's labels again", "owner", owner, "repo", repo)
 	}
 
-	return labelIDs
+	labels := make(map[string]string)
+	var after *string
+	pageSize := 100
+	for {
+		req := graphql.NewRequest(`
+			query($owner: String!, $name: String!, $first: Int!, $after: String) {
+				repository(owner: $owner, name: $name) {
+					labels(first: $first, after: $after) {
+						pageInfo { hasNextPage endCursor }
+						nodes {
+							id
+							name
+						}
+					}
+				}
+			}
+		`)
+		req.Var("owner", owner)
+		req.Var("name", repo)
+		req.Var("after", after)
+
+		var out struct {
+			Repository struct {
+				Labels struct {
+					PageInfo struct {
+						HasNextPage bool    `json:"hasNextPage"`
+						EndCursor   *string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+
+		if err := c.runPage(ctx, req, &pageSize, &out); err != nil {
+			return nil, fmt.Errorf("labels query failed: %w", err)
+		}
+
+		for _, label := range out.Repository.Labels.Nodes {
+			labels[normalizeName(label.Name)] = label.ID
+		}
+
+		pageInfo := out.Repository.Labels.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+	c.cache.setLabels(owner, repo, labels)
+	return labels, nil
 }
 
-// ResolveParentIssueID resolves a parent issue title to its GraphQL node ID.
-func (c *Client) ResolveParentIssueID(ctx context.Context, owner, repo, parentTitle string) (string, error) {
-	if strings.TrimSpace(parentTitle) == "" {
-		return "", fmt.Errorf("parent title is empty")
+// EnsureLabels creates every label in labels that doesn't exist in the repository yet and returns how many were created.
+func (c *Client) EnsureLabels(ctx context.Context, owner, repo string, labels []issuemanager.LabelDefinition) (int, error) {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	existing, err := c.repoLabels(ctx, owner, repo, names...)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, label := range labels {
+		if _, ok := existing[normalizeName(label.Name)]; ok {
+			continue
+		}
+
+		color := label.Color
+		if color == "" {
+			color = DefaultLabelColor
+		}
+		if _, err := c.CreateLabel(ctx, owner, repo, label.Name, color, label.Description); err != nil {
+			return created, fmt.Errorf("create label %q: %w", label.Name, err)
+		}
+		logger.Info("Created label", "label", label.Name, "owner", owner, "repo", repo)
+		existing[normalizeName(label.Name)] = ""
+		created++
 	}
+	return created, nil
+}
 
-	token, err := c.getToken()
+// CreateLabel creates a label in the repository and returns its GraphQL node ID.
+func (c *Client) CreateLabel(ctx context.Context, owner, repo, name, color, description string) (string, error) {
+	repoID, err := c.ResolveRepositoryID(ctx, owner, repo)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("resolve repository id: %w", err)
 	}
 
-	// Search for issues by title using GraphQL
 	req := graphql.NewRequest(`
-		query($query: String!, $first: Int!) {
-			search(query: $query, type: ISSUE, first: $first) {
-				nodes {
-					... on Issue {
-						id
-						title
-						number
-						repository {
-							owner { login }
-							name
-						}
-					}
+		mutation($input: CreateLabelInput!) {
+			createLabel(input: $input) {
+				label {
+					id
+					name
 				}
 			}
 		}
 	`)
+	input := map[string]interface{}{
+		"repositoryId": repoID,
+		"name":         name,
+		"color":        strings.TrimPrefix(color, "#"),
+	}
+	if description != "" {
+		input["description"] = description
+	}
+	req.Var("input", input)
 
-	// Build search query: title + repository scope
-	searchQuery := fmt.Sprintf(`"%s" repo:%s/%s in:title`, strings.TrimSpace(parentTitle), owner, repo)
-	req.Var("query", searchQuery)
-	req.Var("first", 10) // Should be enough to find the parent issue
-	req.Header.Set("Authorization", "Bearer "+token)
+	var resp struct {
+		CreateLabel struct {
+			Label Label `json:"label"`
+		} `json:"createLabel"`
+	}
 
-	var out struct {
-		Search struct {
-			Nodes []struct {
-				ID         string `json:"id"`
-				Title      string `json:"title"`
-				Number     int    `json:"number"`
-				Repository struct {
-					Owner struct {
-						Login string `json:"login"`
-					} `json:"owner"`
-					Name string `json:"name"`
-				} `json:"repository"`
-			} `json:"nodes"`
-		} `json:"search"`
+	if err := c.runMutation(ctx, req, &resp); err != nil {
+		return "", fmt.Errorf("createLabel GraphQL failed: %w", err)
+	}
+	if resp.CreateLabel.Label.ID == "" {
+		return "", fmt.Errorf("createLabel GraphQL returned empty label id")
+	}
+	c.cache.addLabel(owner, repo, name, resp.CreateLabel.Label.ID)
+	return resp.CreateLabel.Label.ID, nil
+}
+
+// ErrParentNotFound is wrapped into the error ResolveParentIssueID returns when no issue has the
+// parent's title, as opposed to the lookup itself failing.
+var ErrParentNotFound = errors.New("parent issue not found")
+
+// ResolveParentIssueID resolves a parent issue title to its GraphQL node ID.
+func (c *Client) ResolveParentIssueID(ctx context.Context, owner, repo, parentTitle string) (string, error) {
+	if strings.TrimSpace(parentTitle) == "" {
+		return "", fmt.Errorf("parent title is empty")
+	}
+
+	// Issues created or updated earlier in this run resolve without waiting for the search index
+	if id, ok := c.cache.issueID(owner, repo, parentTitle); ok {
+		return id, nil
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
+	// Build search query: title + repository scope
+	searchQuery := fmt.Sprintf(`"%s" repo:%s/%s in:title`, strings.TrimSpace(parentTitle), owner, repo)
+	issues, err := c.SearchIssues(ctx, searchQuery, 10) // Should be enough to find the parent issue
+	if err != nil {
 		return "", fmt.Errorf("failed to search for parent issue: %w", err)
 	}
 
 	// Look for exact title match
-	for _, issue := range out.Search.Nodes {
+	for _, issue := range issues {
 		if strings.EqualFold(strings.TrimSpace(issue.Title), strings.TrimSpace(parentTitle)) &&
-			strings.EqualFold(issue.Repository.Owner.Login, owner) &&
-			strings.EqualFold(issue.Repository.Name, repo) {
+			strings.EqualFold(issue.Owner, owner) &&
+			strings.EqualFold(issue.Repo, repo) {
 			return issue.ID, nil
 		}
 	}
 
-	return "", fmt.Errorf("parent issue with title %q not found in %s/%s", parentTitle, owner, repo)
+	return "", fmt.Errorf("%w: no issue titled %q in %s/%s", ErrParentNotFound, parentTitle, owner, repo)
 }
 
-// getIssueNumberFromNodeID retrieves the issue number from a GraphQL node ID.
-func (c *Client) getIssueNumberFromNodeID(ctx context.Context, nodeID string) (int64, error) {
-	token, err := c.getToken()
-	if err != nil {
-		return 0, err
+// SearchIssue represents an issue returned by the GitHub issue search.
+type SearchIssue struct {
+	ID     string   `json:"id"`
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	State  string   `json:"state"`
+	URL    string   `json:"url"`
+	Author string   `json:"author"`
+	Labels []string `json:"labels"`
+	Owner  string   `json:"owner"`
+	Repo   string   `json:"repo"`
+}
+
+// SearchIssues runs a GitHub issue search query and returns up to limit matching issues.
+func (c *Client) SearchIssues(ctx context.Context, query string, limit int) ([]SearchIssue, error) {
+	var results []SearchIssue
+	var after *string
+	maxPageSize := 100
+	for len(results) < limit {
+		pageSize := limit - len(results)
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+
+		req := graphql.NewRequest(`
+			query($query: String!, $first: Int!, $after: String) {
+				search(query: $query, type: ISSUE, first: $first, after: $after) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						... on Issue {
+							id
+							title
+							number
+							state
+							url
+							author { login }
+							labels(first: 20) {
+								nodes { name }
+							}
+							repository {
+								owner { login }
+								name
+							}
+						}
+					}
+				}
+			}
+		`)
+		req.Var("query", query)
+		req.Var("after", after)
+
+		var out struct {
+			Search struct {
+				PageInfo struct {
+					HasNextPage bool    `json:"hasNextPage"`
+					EndCursor   *string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					ID     string `json:"id"`
+					Title  string `json:"title"`
+					Number int    `json:"number"`
+					State  string `json:"state"`
+					URL    string `json:"url"`
+					Author struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					Labels struct {
+						Nodes []struct {
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"labels"`
+					Repository struct {
+						Owner struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+						Name string `json:"name"`
+					} `json:"repository"`
+				} `json:"nodes"`
+			} `json:"search"`
+		}
+
+		if err := c.runPage(ctx, req, &pageSize, &out); err != nil {
+			return nil, fmt.Errorf("search GraphQL query failed: %w", err)
+		}
+		if pageSize < maxPageSize {
+			maxPageSize = pageSize
+		}
+
+		for _, n := range out.Search.Nodes {
+			// Non-issue results (e.g. pull requests) come back as empty objects
+			if n.ID == "" {
+				continue
+			}
+			labels := []string{}
+			for _, l := range n.Labels.Nodes {
+				labels = append(labels, l.Name)
+			}
+			results = append(results, SearchIssue{
+				ID:     n.ID,
+				Number: n.Number,
+				Title:  n.Title,
+				State:  n.State,
+				URL:    n.URL,
+				Author: n.Author.Login,
+				Labels: labels,
+				Owner:  n.Repository.Owner.Login,
+				Repo:   n.Repository.Name,
+			})
+		}
+
+		if !out.Search.PageInfo.HasNextPage || out.Search.PageInfo.EndCursor == nil {
+			break
+		}
+		after = out.Search.PageInfo.EndCursor
 	}
 
+	return results, nil
+}
+
+// getIssueNumberFromNodeID retrieves the issue number from a GraphQL node ID.
+func (c *Client) getIssueNumberFromNodeID(ctx context.Context, nodeID string) (int64, error) {
 	req := graphql.NewRequest(`
 		query($id: ID!) {
 			node(id: $id) {
@@ -1047,7 +2616,6 @@ func (c *Client) getIssueNumberFromNodeID(ctx context.Context, nodeID string) (i
 		}
 	`)
 	req.Var("id", nodeID)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var out struct {
 		Node struct {
@@ -1055,7 +2623,7 @@ func (c *Client) getIssueNumberFromNodeID(ctx context.Context, nodeID string) (i
 		} `json:"node"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
+	if err := c.run(ctx, req, &out); err != nil {
 		return 0, fmt.Errorf("failed to get issue number from node ID: %w", err)
 	}
 
@@ -1064,11 +2632,6 @@ func (c *Client) getIssueNumberFromNodeID(ctx context.Context, nodeID string) (i
 
 // UpdateParentRelationship updates the parent relationship for an existing issue using addSubIssue mutation.
 func (c *Client) UpdateParentRelationship(ctx context.Context, owner, repo, childNodeID, parentTitle string) error {
-	token, err := c.getToken()
-	if err != nil {
-		return err
-	}
-
 	// Resolve parent issue ID from title
 	parentNodeID, err := c.ResolveParentIssueID(ctx, owner, repo, parentTitle)
 	if err != nil {
@@ -1094,7 +2657,6 @@ func (c *Client) UpdateParentRelationship(ctx context.Context, owner, repo, chil
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		AddSubIssue struct {
@@ -1105,7 +2667,7 @@ func (c *Client) UpdateParentRelationship(ctx context.Context, owner, repo, chil
 		} `json:"addSubIssue"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		// Check if the error is about duplicate sub-issues, which means the relationship already exists
 		if strings.Contains(err.Error(), "duplicate sub-issues") {
 			logger.Info("Parent relationship already exists for issue")
@@ -1118,18 +2680,25 @@ func (c *Client) UpdateParentRelationship(ctx context.Context, owner, repo, chil
 }
 
 // RemoveParentRelationship removes a parent-child relationship using removeSubIssue mutation.
+// If the title search doesn't find the parent (e.g. the search index hasn't caught up yet), the
+// child's current parent is used instead, but only when its title matches parentTitle.
 func (c *Client) RemoveParentRelationship(ctx context.Context, owner, repo, childNodeID, parentTitle string) error {
-	token, err := c.getToken()
-	if err != nil {
-		return err
-	}
-
 	// Resolve parent issue ID from title
 	parentNodeID, err := c.ResolveParentIssueID(ctx, owner, repo, parentTitle)
 	if err != nil {
-		return fmt.Errorf("failed to resolve parent issue ID: %w", err)
+		current, parentErr := c.getIssueParent(ctx, childNodeID)
+		if parentErr != nil || current == nil || !strings.EqualFold(strings.TrimSpace(current.Title), strings.TrimSpace(parentTitle)) {
+			return fmt.Errorf("failed to resolve parent issue ID: %w", err)
+		}
+		logger.Debug("Parent title did not resolve, using the issue's current parent", "title", parentTitle, "parent", current.Title)
+		parentNodeID = current.ID
 	}
 
+	return c.RemoveParentRelationshipByID(ctx, parentNodeID, childNodeID)
+}
+
+// RemoveParentRelationshipByID removes a parent-child relationship given both issues' node IDs.
+func (c *Client) RemoveParentRelationshipByID(ctx context.Context, parentNodeID, childNodeID string) error {
 	// Use removeSubIssue mutation to break parent-child relationship
 	req := graphql.NewRequest(`
 		mutation($input: RemoveSubIssueInput!) {
@@ -1148,7 +2717,6 @@ func (c *Client) RemoveParentRelationship(ctx context.Context, owner, repo, chil
 	}
 
 	req.Var("input", input)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var resp struct {
 		RemoveSubIssue struct {
@@ -1159,68 +2727,50 @@ func (c *Client) RemoveParentRelationship(ctx context.Context, owner, repo, chil
 		} `json:"removeSubIssue"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &resp); err != nil {
+	if err := c.runMutation(ctx, req, &resp); err != nil {
 		return fmt.Errorf("removeSubIssue GraphQL failed: %w", err)
 	}
 
 	return nil
 }
 
-// ValidateProjectID validates that a project exists for the given organization.
-func (c *Client) ValidateProjectID(ctx context.Context, owner string, project string) (bool, error) {
-	token, err := c.getToken()
-	if err != nil {
-		return false, err
-	}
-
-	request := graphql.NewRequest(`
-	query OrgProjects($login: String!, $first: Int = 20, $after: String) {
-		organization(login: $login) {
-			projectsV2(
-			first: $first
-			after: $after
-			orderBy: { field: UPDATED_AT, direction: DESC }
-			) {
-				totalCount
-				pageInfo { hasNextPage endCursor }
-				nodes {
-					id
-					number
-					title
-					url
-					public
-					shortDescription
-					updatedAt
+// getIssueParent returns the current parent of the issue with the given node ID, or nil if it has none.
+func (c *Client) getIssueParent(ctx context.Context, childNodeID string) (*IssueRef, error) {
+	req := graphql.NewRequest(`
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					parent { id number title }
 				}
 			}
 		}
-	}
 	`)
-	request.Var("login", owner)
-	request.Var("first", 10)
-	request.Header.Set("Authorization", "Bearer "+token)
+	req.Var("id", childNodeID)
 
-	var resp struct {
+	var out struct {
 		Node struct {
-			ID string `json:"id"`
+			Parent *IssueRef `json:"parent"`
 		} `json:"node"`
 	}
 
-	if err := c.GraphQL.Run(ctx, request, &resp); err != nil {
-		return false, fmt.Errorf("failed to query GitHub GraphQL API: %w", err)
+	if err := c.run(ctx, req, &out); err != nil {
+		return nil, fmt.Errorf("failed to query issue parent: %w", err)
 	}
-
-	return resp.Node.ID != "", nil
-
+	return out.Node.Parent, nil
 }
 
-// GetIssueTypes retrieves all issue types for a repository using GraphQL.
-func (c *Client) GetIssueTypes(ctx context.Context, owner, repo string) ([]IssueType, error) {
-	token, err := c.getToken()
+// ValidateProjectID reports whether the organization or user owner has a project titled project
+// (ignoring case).
+func (c *Client) ValidateProjectID(ctx context.Context, owner string, project string) (bool, error) {
+	id, err := c.findProjectID(ctx, owner, project)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
+	return id != "", nil
+}
 
+// GetIssueTypes retrieves all issue types for a repository using GraphQL.
+func (c *Client) GetIssueTypes(ctx context.Context, owner, repo string) ([]IssueType, error) {
 	req := graphql.NewRequest(`
 		query($owner: String!, $name: String!) {
 			repository(owner: $owner, name: $name) {
@@ -1235,7 +2785,6 @@ func (c *Client) GetIssueTypes(ctx context.Context, owner, repo string) ([]Issue
 	`)
 	req.Var("owner", owner)
 	req.Var("name", repo)
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	var out struct {
 		Repository struct {
@@ -1245,7 +2794,7 @@ func (c *Client) GetIssueTypes(ctx context.Context, owner, repo string) ([]Issue
 		} `json:"repository"`
 	}
 
-	if err := c.GraphQL.Run(ctx, req, &out); err != nil {
+	if err := c.run(ctx, req, &out); err != nil {
 		return nil, fmt.Errorf("failed to execute issue types GraphQL query: %w", err)
 	}
 
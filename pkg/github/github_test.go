@@ -0,0 +1,382 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+// replyIssue answers issue-by-number lookups with the node ID "I_<number>".
+func replyIssue(f *fakeGitHub) {
+	f.on("issue(number", func(r fakeRequest) interface{} {
+		number := int(r.Variables["number"].(float64))
+		return obj{"repository": obj{"issue": obj{"id": fmt.Sprintf("I_%d", number), "number": number}}}
+	})
+}
+
+// replyUsers answers user lookups for the given logins, mapping each to its node ID.
+func replyUsers(f *fakeGitHub, users map[string]string) {
+	f.on("user(login", func(r fakeRequest) interface{} {
+		if id, ok := users[r.Variables["login"].(string)]; ok {
+			return obj{"user": obj{"id": id}}
+		}
+		return fakeErrors{"Could not resolve to a User with the login of '" + r.Variables["login"].(string) + "'."}
+	})
+}
+
+func replyUpdateIssue(f *fakeGitHub) {
+	f.on("updateIssue", func(r fakeRequest) interface{} {
+		return obj{"updateIssue": obj{"issue": obj{"id": r.input()["id"], "number": 1}}}
+	})
+}
+
+func TestUpdateIssueAssignees(t *testing.T) {
+	tests := []struct {
+		name      string
+		assignees []string
+		want      interface{} // assigneeIds sent, nil when the field is left out
+	}{
+		{name: "resolved logins replace the assignees", assignees: []string{"octocat", "ghost"}, want: []interface{}{"U_octocat"}},
+		{name: "no login resolves", assignees: []string{"ghost"}},
+		{name: "no assignees in the file", assignees: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			replyIssue(f)
+			replyUsers(f, map[string]string{"octocat": "U_octocat"})
+			replyUpdateIssue(f)
+
+			issue := issuemanager.Issue{Title: "T", Assignees: tt.assignees}
+			if result := c.UpdateIssue(context.Background(), "octo", "hello", issue, 1); result.Err != nil {
+				t.Fatalf("UpdateIssue: %v", result.Err)
+			}
+
+			input := f.calls("updateIssue")[0].input()
+			got, sent := input["assigneeIds"]
+			if tt.want == nil {
+				if sent {
+					t.Errorf("assigneeIds = %v, want the field left out", got)
+				}
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assigneeIds = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// replySearch answers issue searches with issues in octo/hello, given as node ID and title pairs.
+func replySearch(f *fakeGitHub, idsAndTitles ...string) {
+	var nodes []obj
+	for i := 0; i+1 < len(idsAndTitles); i += 2 {
+		nodes = append(nodes, obj{
+			"id":         idsAndTitles[i],
+			"title":      idsAndTitles[i+1],
+			"repository": obj{"owner": obj{"login": "octo"}, "name": "hello"},
+		})
+	}
+	f.reply("search(query", obj{"search": obj{"pageInfo": obj{"hasNextPage": false}, "nodes": nodes}})
+}
+
+func TestRemoveParentRelationshipFallsBackToMatchingParent(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentParent string
+		wantRemoved   bool
+	}{
+		{name: "current parent has the title", currentParent: "auth EPIC", wantRemoved: true},
+		{name: "current parent has another title", currentParent: "Billing epic", wantRemoved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			// The search index doesn't know the parent yet
+			replySearch(f)
+			f.reply("parent { id number title }", obj{"node": obj{"parent": obj{"id": "I_parent", "number": 1, "title": tt.currentParent}}})
+			f.reply("removeSubIssue", obj{"removeSubIssue": obj{"issue": obj{"id": "I_parent"}}})
+
+			err := c.RemoveParentRelationship(context.Background(), "octo", "hello", "I_child", "Auth epic")
+			removed := f.calls("removeSubIssue")
+			if tt.wantRemoved {
+				if err != nil {
+					t.Fatalf("RemoveParentRelationship: %v", err)
+				}
+				if len(removed) != 1 || removed[0].input()["issueId"] != "I_parent" {
+					t.Errorf("removeSubIssue calls = %v, want one for I_parent", removed)
+				}
+				return
+			}
+			if err == nil {
+				t.Error("RemoveParentRelationship succeeded, want the resolve error")
+			}
+			if len(removed) != 0 {
+				t.Errorf("removeSubIssue sent %d times, want 0", len(removed))
+			}
+		})
+	}
+}
+
+func TestCreateMissingParents(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     func(fakeRequest) interface{}
+		wantIssues int // createIssue mutations: the placeholder and the child
+		wantErr    bool
+	}{
+		{
+			name:       "parent doesn't exist",
+			search:     func(fakeRequest) interface{} { return obj{"search": obj{"nodes": []obj{}}} },
+			wantIssues: 2,
+		},
+		{
+			name:    "parent lookup fails",
+			search:  func(fakeRequest) interface{} { return fakeErrors{"Something went wrong while executing your query."} },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+			f.on("search(query", tt.search)
+			replyCreateIssue(f)
+
+			issues := []issuemanager.Issue{{Title: "Child", Parent: "Epic"}}
+			results := c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{CreateMissingParents: true})
+
+			if got := len(f.calls("createIssue")); got != tt.wantIssues {
+				t.Errorf("createIssue sent %d times, want %d", got, tt.wantIssues)
+			}
+			child := results[len(results)-1]
+			if child.Issue.Title != "Child" || (child.Err != nil) != tt.wantErr {
+				t.Errorf("child result = %+v, want error %v", child, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateIssuesRecoversIDByExternalID(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     interface{}
+		wantUpdate bool
+		wantErr    bool
+	}{
+		{
+			name:       "existing marker updates the issue",
+			search:     obj{"search": obj{"pageInfo": obj{"hasNextPage": false}, "nodes": []obj{{"id": "I_7", "number": 7}}}},
+			wantUpdate: true,
+		},
+		{
+			name:    "search error creates nothing",
+			search:  fakeErrors{"Something went wrong while executing your query."},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+			f.reply("search(query", tt.search)
+			f.reply("issue(number", obj{"repository": obj{"issue": obj{
+				"id": "I_7", "number": 7, "body": "Imported\n\n" + issuemanager.ExternalIDMarker("JIRA-1"),
+			}}})
+			replyUpdateIssue(f)
+			replyCreateIssue(f)
+
+			issues := []issuemanager.Issue{{Title: "Imported", ExternalID: "JIRA-1"}}
+			results := c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{})
+
+			if got := len(f.calls("createIssue")); got != 0 {
+				t.Errorf("createIssue sent %d times, want 0", got)
+			}
+			if got := len(f.calls("updateIssue")) > 0; got != tt.wantUpdate {
+				t.Errorf("updateIssue sent = %v, want %v", got, tt.wantUpdate)
+			}
+			if len(results) != 1 || (results[0].Err != nil) != tt.wantErr {
+				t.Fatalf("results = %+v, want one with error %v", results, tt.wantErr)
+			}
+			if tt.wantUpdate && results[0].Issue.Id != "7" {
+				t.Errorf("recovered id = %q, want 7", results[0].Issue.Id)
+			}
+		})
+	}
+}
+
+func TestCreateIssuesOnProcessedSkipsFailures(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+	number := 0
+	f.on("createIssue", func(r fakeRequest) interface{} {
+		if r.input()["title"] == "Broken" {
+			return fakeErrors{"Title is invalid"}
+		}
+		number++
+		return obj{"createIssue": obj{"issue": obj{"id": fmt.Sprintf("I_%d", number), "number": number}}}
+	})
+
+	var processed []string
+	issues := []issuemanager.Issue{{Title: "One"}, {Title: "Broken"}, {Title: "Two"}}
+	results := c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{
+		OnProcessed: func(result CreateResult) { processed = append(processed, result.Issue.Title) },
+	})
+
+	if len(results) != 3 || results[1].Err == nil {
+		t.Fatalf("results = %+v, want the second to fail", results)
+	}
+	// A failed issue must not be checkpointed, or a resumed run would skip it
+	if want := []string{"One", "Two"}; !reflect.DeepEqual(processed, want) {
+		t.Errorf("OnProcessed called for %v, want %v", processed, want)
+	}
+}
+
+func TestCreateIssuesStopsWhenCancelled(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+	replyCreateIssue(f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	issues := []issuemanager.Issue{{Title: "One"}, {Title: "Two"}, {Title: "Three"}}
+	results := c.CreateIssues(ctx, "octo", "hello", issues, CreateOptions{
+		OnProcessed: func(CreateResult) { cancel() },
+	})
+
+	if got := len(f.calls("createIssue")); got != 1 {
+		t.Errorf("createIssue sent %d times, want 1", got)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if results[0].Err != nil {
+		t.Errorf("first issue: %v", results[0].Err)
+	}
+	for _, result := range results[1:] {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("%s: err = %v, want context.Canceled", result.Issue.Title, result.Err)
+		}
+	}
+}
+
+func TestCreateMissingLabelOnce(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	c.CreateMissingLabels = true
+	f.reply("repository(owner: $owner, name: $name) { id }", obj{"repository": obj{"id": "R_1"}})
+	f.reply("labels(first: $first", obj{"repository": obj{"labels": obj{
+		"pageInfo": obj{"hasNextPage": false},
+		"nodes":    []obj{{"id": "L_bug", "name": "bug"}},
+	}}})
+	f.reply("createLabel", obj{"createLabel": obj{"label": obj{"id": "L_triage", "name": "needs-triage"}}})
+	replyCreateIssue(f)
+
+	issues := []issuemanager.Issue{
+		{Title: "One", Labels: []string{"needs-triage", "bug"}},
+		{Title: "Two", Labels: []string{"Needs-Triage"}},
+	}
+	for _, result := range c.CreateIssues(context.Background(), "octo", "hello", issues, CreateOptions{}) {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Issue.Title, result.Err)
+		}
+	}
+
+	created := f.calls("createLabel")
+	if len(created) != 1 || created[0].input()["name"] != "needs-triage" || created[0].input()["color"] != DefaultLabelColor {
+		t.Errorf("createLabel calls = %+v, want one for needs-triage", created)
+	}
+	want := [][]interface{}{{"L_triage", "L_bug"}, {"L_triage"}}
+	for i, call := range f.calls("createIssue") {
+		if got := call.input()["labelIds"]; !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("issue %d labelIds = %v, want %v", i+1, got, want[i])
+		}
+	}
+}
+
+func TestResolveLabelOnSecondPage(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("labels(first: $first", pagedNodes(
+		[]obj{{"id": "L_1", "name": "bug"}, {"id": "L_2", "name": "ui"}, {"id": "L_3", "name": "Good First Issue"}},
+		func(labels obj) obj { return obj{"repository": obj{"labels": labels}} },
+	))
+
+	got, err := c.resolveLabelIDs(context.Background(), "octo", "hello", []string{"good first issue"}, nil)
+	if err != nil || !reflect.DeepEqual(got, []string{"L_3"}) {
+		t.Errorf("resolveLabelIDs = %v, %v, want [L_3]", got, err)
+	}
+}
+
+func TestUpdateIssueFieldsLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		want    interface{} // labelIds sent, nil when no update is sent
+		wantErr bool
+	}{
+		{name: "every label resolves", labels: []string{"ui", "bug"}, want: []interface{}{"L_ui", "L_bug"}},
+		{name: "an unknown label fails the row", labels: []string{"bug", "gone"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			replyIssue(f)
+			f.reply("labels(first: $first", obj{"repository": obj{"labels": obj{
+				"pageInfo": obj{"hasNextPage": false},
+				"nodes":    []obj{{"id": "L_bug", "name": "bug"}, {"id": "L_ui", "name": "ui"}},
+			}}})
+			replyUpdateIssue(f)
+
+			err := c.UpdateIssueFields(context.Background(), "octo", "hello", 1, IssueFieldUpdate{Labels: tt.labels})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateIssueFields error = %v, want error %v", err, tt.wantErr)
+			}
+			updates := f.calls("updateIssue")
+			if tt.want == nil {
+				if len(updates) != 0 {
+					t.Errorf("sent %d updates, want none", len(updates))
+				}
+				return
+			}
+			if len(updates) != 1 || !reflect.DeepEqual(updates[0].input()["labelIds"], tt.want) {
+				t.Errorf("updates = %v, want one with labelIds %v", updates, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateIssueTypeTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType string
+		want     interface{}
+	}{
+		{name: "set a type on an untyped issue", fileType: "bug", want: "T_bug"},
+		{name: "clear the type removed from the file", fileType: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			replyIssue(f)
+			f.reply("issueTypes(first", obj{"repository": obj{"issueTypes": obj{"nodes": []obj{{"id": "T_bug", "name": "Bug"}}}}})
+			replyUpdateIssue(f)
+
+			issue := issuemanager.Issue{Title: "T", Type: tt.fileType}
+			if result := c.UpdateIssueWithTypeGraphQL(context.Background(), "octo", "hello", issue, 1); result.Err != nil {
+				t.Fatalf("UpdateIssueWithTypeGraphQL: %v", result.Err)
+			}
+
+			got, sent := f.calls("updateIssue")[0].input()["issueTypeId"]
+			if !sent || got != tt.want {
+				t.Errorf("issueTypeId = %v (sent %v), want %v", got, sent, tt.want)
+			}
+		})
+	}
+}
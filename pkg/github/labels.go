@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/machinebox/graphql"
+
+	"github-issue-manager/pkg/issuemanager"
+	"github-issue-manager/pkg/logger"
+)
+
+// reconcileLabels makes the labels of an existing issue match its front matter: missing labels are
+// added and, with PruneLabels, labels no longer in the front matter are removed. Without PruneLabels
+// labels added on GitHub (e.g. by triage) are kept. Nothing is pruned when any front matter label
+// can't be resolved, since its ID would be missing from the labels to keep.
+func (c *Client) reconcileLabels(ctx context.Context, owner, repo, issueNodeID string, issue issuemanager.Issue) error {
+	if len(issue.Labels) == 0 && !c.PruneLabels {
+		return nil
+	}
+
+	current, err := c.issueLabelIDs(ctx, issueNodeID)
+	if err != nil {
+		return err
+	}
+
+	labelIDs, resolveErr := c.resolveLabelIDs(ctx, owner, repo, issue.Labels, issue.LabelDefinitions)
+	desired := make(map[string]bool)
+	var add []string
+	for _, id := range labelIDs {
+		desired[id] = true
+		if _, ok := current[id]; !ok {
+			add = append(add, id)
+		}
+	}
+
+	var remove, removedNames []string
+	if c.PruneLabels && resolveErr == nil {
+		for id, name := range current {
+			if !desired[id] {
+				remove = append(remove, id)
+				removedNames = append(removedNames, name)
+			}
+		}
+	}
+
+	if len(add) > 0 {
+		if err := c.changeLabels(ctx, "addLabelsToLabelable", issueNodeID, add); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if err := c.changeLabels(ctx, "removeLabelsFromLabelable", issueNodeID, remove); err != nil {
+			return err
+		}
+		logger.Info("Pruned labels", "issue", issue.Title, "labels", removedNames)
+	}
+	if resolveErr != nil && c.PruneLabels {
+		return fmt.Errorf("labels not pruned: %w", resolveErr)
+	}
+	return resolveErr
+}
+
+// issueLabelIDs returns the labels currently on an issue, keyed by label node ID.
+func (c *Client) issueLabelIDs(ctx context.Context, issueNodeID string) (map[string]string, error) {
+	labels := make(map[string]string)
+	var after *string
+	pageSize := 100
+	for {
+		req := graphql.NewRequest(`
+			query($id: ID!, $first: Int!, $after: String) {
+				node(id: $id) {
+					... on Issue {
+						labels(first: $first, after: $after) {
+							pageInfo { hasNextPage endCursor }
+							nodes { id name }
+						}
+					}
+				}
+			}
+		`)
+		req.Var("id", issueNodeID)
+		req.Var("after", after)
+
+		var out struct {
+			Node struct {
+				Labels struct {
+					PageInfo struct {
+						HasNextPage bool    `json:"hasNextPage"`
+						EndCursor   *string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+			} `json:"node"`
+		}
+		if err := c.runPage(ctx, req, &pageSize, &out); err != nil {
+			return nil, fmt.Errorf("issue labels query failed: %w", err)
+		}
+
+		for _, label := range out.Node.Labels.Nodes {
+			labels[label.ID] = label.Name
+		}
+
+		pageInfo := out.Node.Labels.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+	return labels, nil
+}
+
+// changeLabels runs addLabelsToLabelable or removeLabelsFromLabelable for an issue.
+func (c *Client) changeLabels(ctx context.Context, mutation, issueNodeID string, labelIDs []string) error {
+	inputType := "AddLabelsToLabelableInput"
+	if mutation == "removeLabelsFromLabelable" {
+		inputType = "RemoveLabelsFromLabelableInput"
+	}
+	req := graphql.NewRequest(fmt.Sprintf(`
+		mutation($input: %s!) {
+			%s(input: $input) {
+				clientMutationId
+			}
+		}
+	`, inputType, mutation))
+	req.Var("input", map[string]interface{}{
+		"labelableId": issueNodeID,
+		"labelIds":    labelIDs,
+	})
+
+	if err := c.runMutation(ctx, req, &struct{}{}); err != nil {
+		return fmt.Errorf("%s GraphQL failed: %w", mutation, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+// pagedNodes answers a paginated connection two nodes at a time, following the $after cursor.
+func pagedNodes(nodes []obj, wrap func(connection obj) obj) func(fakeRequest) interface{} {
+	return func(r fakeRequest) interface{} {
+		start := 0
+		if after, ok := r.Variables["after"].(string); ok {
+			start, _ = strconv.Atoi(after)
+		}
+		end := start + 2
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		pageInfo := obj{"hasNextPage": end < len(nodes), "endCursor": strconv.Itoa(end)}
+		return wrap(obj{"pageInfo": pageInfo, "nodes": nodes[start:end]})
+	}
+}
+
+func TestIssueLabelIDsFollowsPages(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("labels(first", pagedNodes(
+		[]obj{{"id": "L_1", "name": "bug"}, {"id": "L_2", "name": "ui"}, {"id": "L_3", "name": "p1"}},
+		func(labels obj) obj { return obj{"node": obj{"labels": labels}} },
+	))
+
+	got, err := c.issueLabelIDs(context.Background(), "I_1")
+	if err != nil {
+		t.Fatalf("issueLabelIDs: %v", err)
+	}
+	want := map[string]string{"L_1": "bug", "L_2": "ui", "L_3": "p1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issueLabelIDs = %v, want %v", got, want)
+	}
+	if calls := len(f.calls("labels(first")); calls != 2 {
+		t.Errorf("labels query sent %d times, want 2", calls)
+	}
+}
+
+func TestResolveLabelIDsKeepsFrontMatterOrder(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("labels(first: $first", pagedNodes(
+		[]obj{{"id": "L_bug", "name": "bug"}, {"id": "L_p1", "name": "P1"}, {"id": "L_ui", "name": "ui"}},
+		func(labels obj) obj { return obj{"repository": obj{"labels": labels}} },
+	))
+
+	got, err := c.resolveLabelIDs(context.Background(), "octo", "hello", []string{"ui", "Bug", "p1", " bug ", "UI", "missing"}, nil)
+	if want := []string{"L_ui", "L_bug", "L_p1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveLabelIDs = %v, want %v", got, want)
+	}
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("resolveLabelIDs error = %v, want it to name the missing label", err)
+	}
+}
+
+// replyLabels answers the issue's labels query with current and the repository's labels query with
+// repo, or with repo as is when it is a fakeStatus or fakeErrors.
+func replyLabels(f *fakeGitHub, current []obj, repo interface{}) {
+	f.on("labels(first", func(r fakeRequest) interface{} {
+		if _, ok := r.Variables["id"]; ok {
+			return obj{"node": obj{"labels": obj{"pageInfo": obj{"hasNextPage": false}, "nodes": current}}}
+		}
+		if nodes, ok := repo.([]obj); ok {
+			return obj{"repository": obj{"labels": obj{"pageInfo": obj{"hasNextPage": false}, "nodes": nodes}}}
+		}
+		return repo
+	})
+	f.reply("Labelable(input", obj{})
+}
+
+// labelChanges returns the label IDs sent by the add and remove label mutations.
+func labelChanges(f *fakeGitHub) (added, removed []interface{}) {
+	for _, call := range f.calls("addLabelsToLabelable(") {
+		added = append(added, call.input()["labelIds"].([]interface{})...)
+	}
+	for _, call := range f.calls("removeLabelsFromLabelable(") {
+		removed = append(removed, call.input()["labelIds"].([]interface{})...)
+	}
+	return added, removed
+}
+
+func TestReconcileLabels(t *testing.T) {
+	current := []obj{{"id": "L_bug", "name": "bug"}, {"id": "L_triage", "name": "triage"}}
+	repo := []obj{{"id": "L_bug", "name": "bug"}, {"id": "L_ui", "name": "ui"}, {"id": "L_triage", "name": "triage"}}
+	tests := []struct {
+		name        string
+		prune       bool
+		repo        interface{}
+		labels      []string
+		wantErr     bool
+		wantAdded   []interface{}
+		wantRemoved []interface{}
+	}{
+		{name: "prune removes only the extra labels", prune: true, repo: repo, labels: []string{"bug", "ui"}, wantAdded: []interface{}{"L_ui"}, wantRemoved: []interface{}{"L_triage"}},
+		{name: "nothing is pruned without the flag", repo: repo, labels: []string{"bug", "ui"}, wantAdded: []interface{}{"L_ui"}},
+		{name: "an unknown label stops pruning", prune: true, repo: repo, labels: []string{"ui", "gone"}, wantErr: true, wantAdded: []interface{}{"L_ui"}},
+		{name: "a failed label lookup removes nothing", prune: true, repo: fakeErrors{"boom"}, labels: []string{"bug", "ui"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			c.PruneLabels = tt.prune
+			replyLabels(f, current, tt.repo)
+
+			err := c.reconcileLabels(context.Background(), "octo", "hello", "I_1", issuemanager.Issue{Title: "T", Labels: tt.labels})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reconcileLabels error = %v, want error %v", err, tt.wantErr)
+			}
+			added, removed := labelChanges(f)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added %v, want %v", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
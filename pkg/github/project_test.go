@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github-issue-manager/pkg/issuemanager"
+)
+
+// replyProjects answers owner project listings two projects per page.
+func replyProjects(f *fakeGitHub, titles ...string) {
+	var nodes []obj
+	for i, title := range titles {
+		nodes = append(nodes, obj{"id": "PVT_" + title, "number": i + 1, "title": title})
+	}
+	f.on("projectsV2(", pagedNodes(nodes, func(projects obj) obj {
+		return obj{"repositoryOwner": obj{"projectsV2": projects}}
+	}))
+}
+
+func TestProjectPager(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	replyProjects(f, "Roadmap", "Bugs", "Q3 Board")
+	ctx := context.Background()
+
+	projects, err := c.ListProjects(ctx, "octo")
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 3 || projects[2].Title != "Q3 Board" || projects[2].Number != 3 {
+		t.Errorf("ListProjects = %+v, want all 3 projects", projects)
+	}
+	if got := len(f.calls("projectsV2(")); got != 2 {
+		t.Errorf("ListProjects sent %d queries, want 2", got)
+	}
+
+	// A project on the first page doesn't fetch the second
+	if id, err := c.ResolveProjectID(ctx, "octo", "bugs"); err != nil || id != "PVT_Bugs" {
+		t.Errorf("ResolveProjectID = %q, %v, want PVT_Bugs", id, err)
+	}
+	if got := len(f.calls("projectsV2(")); got != 3 {
+		t.Errorf("ResolveProjectID sent %d queries, want 1", got-2)
+	}
+}
+
+func TestResolveProjectIDForOrganizationAndUser(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	// repositoryOwner resolves organizations and users alike; unknown logins come back null
+	owners := map[string]obj{
+		"my-org":  {"projectsV2": obj{"nodes": []obj{{"id": "PVT_org", "title": "Roadmap"}}}},
+		"octocat": {"projectsV2": obj{"nodes": []obj{{"id": "PVT_user", "title": "Side Project"}}}},
+	}
+	f.on("projectsV2(", func(r fakeRequest) interface{} {
+		owner, ok := owners[r.Variables["login"].(string)]
+		if !ok {
+			return obj{"repositoryOwner": nil}
+		}
+		return obj{"repositoryOwner": owner}
+	})
+
+	tests := []struct {
+		owner, project string
+		want           string
+		wantErr        bool
+	}{
+		{owner: "my-org", project: "roadmap", want: "PVT_org"},
+		{owner: "octocat", project: "Side Project", want: "PVT_user"},
+		{owner: "octocat", project: "Roadmap", wantErr: true},
+		{owner: "nobody", project: "Roadmap", wantErr: true},
+	}
+	for _, tt := range tests {
+		id, err := c.ResolveProjectID(context.Background(), tt.owner, tt.project)
+		if (err != nil) != tt.wantErr || id != tt.want {
+			t.Errorf("ResolveProjectID(%q, %q) = %q, %v; want %q, error %v", tt.owner, tt.project, id, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateProjectID(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	replyProjects(f, "Roadmap", "Bugs", "Q3 Board")
+
+	for project, want := range map[string]bool{"q3 board": true, "Backlog": false} {
+		got, err := c.ValidateProjectID(context.Background(), "octo", project)
+		if err != nil {
+			t.Fatalf("ValidateProjectID(%q): %v", project, err)
+		}
+		if got != want {
+			t.Errorf("ValidateProjectID(%q) = %v, want %v", project, got, want)
+		}
+	}
+}
+
+// replyStatusField answers field lookups with a Status single-select field.
+func replyStatusField(f *fakeGitHub) {
+	f.reply("field(name", obj{"node": obj{"field": obj{
+		"id": "F_status", "name": "Status", "dataType": "SINGLE_SELECT",
+		"options": []obj{{"id": "O_todo", "name": "Todo"}, {"id": "O_progress", "name": "In Progress"}},
+	}}})
+}
+
+func TestSetProjectItemFieldValueOption(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	replyStatusField(f)
+	f.reply("updateProjectV2ItemFieldValue", obj{"updateProjectV2ItemFieldValue": obj{"projectV2Item": obj{"id": "PVTI_1"}}})
+	ctx := context.Background()
+
+	if err := c.SetProjectItemFieldValue(ctx, "PVT_1", "PVTI_1", "Status", "in progress"); err != nil {
+		t.Fatalf("SetProjectItemFieldValue: %v", err)
+	}
+	updates := f.calls("updateProjectV2ItemFieldValue")
+	if len(updates) != 1 {
+		t.Fatalf("sent %d updates, want 1", len(updates))
+	}
+	value, _ := updates[0].input()["value"].(map[string]interface{})
+	if updates[0].input()["fieldId"] != "F_status" || value["singleSelectOptionId"] != "O_progress" {
+		t.Errorf("update input = %v, want F_status set to O_progress", updates[0].input())
+	}
+
+	err := c.SetProjectItemFieldValue(ctx, "PVT_1", "PVTI_1", "Status", "Blocked")
+	if err == nil || !strings.Contains(err.Error(), `option "Blocked" not found`) {
+		t.Errorf("unknown option: err = %v, want option not found", err)
+	}
+	if got := len(f.calls("updateProjectV2ItemFieldValue")); got != 1 {
+		t.Errorf("unknown option sent an update")
+	}
+}
+
+func TestAddToProjectKeepsExistingStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		boardStatus string
+		force       bool
+		wantUpdate  bool
+	}{
+		{name: "new item", boardStatus: "", wantUpdate: true},
+		{name: "status differs on the board", boardStatus: "In Progress", wantUpdate: false},
+		{name: "status differs, forced", boardStatus: "In Progress", force: true, wantUpdate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeGitHub(t)
+			c.ForceStatus = tt.force
+			replyIssue(f)
+			replyProjects(f, "Roadmap")
+			replyStatusField(f)
+			var status obj
+			if tt.boardStatus != "" {
+				status = obj{"name": tt.boardStatus}
+			}
+			f.reply("fieldValueByName", obj{"node": obj{"projectItems": obj{"nodes": []obj{
+				{"project": obj{"id": "PVT_Roadmap"}, "fieldValueByName": status},
+			}}}})
+			f.reply("addProjectV2ItemById", obj{"addProjectV2ItemById": obj{"item": obj{"id": "PVTI_1"}}})
+			f.reply("updateProjectV2ItemFieldValue", obj{"updateProjectV2ItemFieldValue": obj{"projectV2Item": obj{"id": "PVTI_1"}}})
+
+			issue := issuemanager.Issue{
+				Title:         "T",
+				Project:       "Roadmap",
+				ProjectFields: []issuemanager.ProjectFieldValue{{Field: "Status", Value: "Todo"}},
+			}
+			c.addToProject(context.Background(), "octo", "hello", issue, 1)
+
+			if got := len(f.calls("updateProjectV2ItemFieldValue")) == 1; got != tt.wantUpdate {
+				t.Errorf("status updated = %v, want %v", got, tt.wantUpdate)
+			}
+		})
+	}
+}
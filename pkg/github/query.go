@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/machinebox/graphql"
+
+	"github-issue-manager/pkg/logger"
+)
+
+// minPageSize is the smallest page size runPage shrinks to before giving up on a query.
+const minPageSize = 5
+
+// queryLimitMarkers are fragments of the errors GitHub returns when a query requests too many
+// nodes (MAX_NODE_LIMIT_EXCEEDED) or times out; both go away with a smaller page. Other errors
+// merely mentioning limits are not retried this way.
+var queryLimitMarkers = []string{
+	"max_node_limit_exceeded",
+	"which exceeds the maximum limit of",
+	"this may be the result of a timeout",
+}
+
+// isQueryLimitError reports whether GitHub rejected a query for exceeding its node limit or timing out.
+func isQueryLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range queryLimitMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// runPage runs one page of a paginated query whose page size is the "first" variable. When GitHub
+// rejects the query for exceeding its node limit or timing out, the page size is halved and the
+// page retried; *pageSize is updated so the caller keeps the smaller size for later pages.
+func (c *Client) runPage(ctx context.Context, req *graphql.Request, pageSize *int, resp interface{}) error {
+	for {
+		req.Var("first", *pageSize)
+		err := c.run(ctx, req, resp)
+		if err == nil || !isQueryLimitError(err) {
+			return err
+		}
+		if *pageSize <= minPageSize {
+			return fmt.Errorf("query exceeds GitHub's node limit or times out even at page size %d: %w", *pageSize, err)
+		}
+		if !c.retries.take() {
+			return fmt.Errorf("%w: %v", ErrRetryBudgetExhausted, err)
+		}
+
+		*pageSize /= 2
+		if *pageSize < minPageSize {
+			*pageSize = minPageSize
+		}
+		logger.Warn("GitHub rejected a query for exceeding its limits, retrying with a smaller page size", "pageSize", *pageSize, "error", err)
+	}
+}
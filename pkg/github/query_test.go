@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIsQueryLimitError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"graphql: By the time this query traverses to the nodes connection, it is requesting up to 1,000,000 possible nodes which exceeds the maximum limit of 500,000.", true},
+		{"graphql: MAX_NODE_LIMIT_EXCEEDED", true},
+		{"graphql: Something went wrong while executing your query. This may be the result of a timeout, or it could be a GitHub bug.", true},
+		{"graphql: The complexity field of this issue type is read-only", false},
+		{"graphql: Label name exceeds the maximum length", false},
+		{"graphql: Could not resolve to a Repository with the name 'octo/hello'.", false},
+	}
+	for _, tt := range tests {
+		if got := isQueryLimitError(errors.New(tt.message)); got != tt.want {
+			t.Errorf("isQueryLimitError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestRunPageShrinksOnNodeLimit(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("labels(first: $first", func(r fakeRequest) interface{} {
+		if r.Variables["first"].(float64) > 25 {
+			return fakeErrors{"By the time this query traverses to the labels connection, it is requesting up to 600,000 possible nodes which exceeds the maximum limit of 500,000."}
+		}
+		return obj{"repository": obj{"labels": obj{"pageInfo": obj{"hasNextPage": false}, "nodes": []obj{{"id": "L_1", "name": "bug"}}}}}
+	})
+
+	labels, err := c.repoLabels(context.Background(), "octo", "hello")
+	if err != nil {
+		t.Fatalf("repoLabels: %v", err)
+	}
+	if labels["bug"] != "L_1" {
+		t.Errorf("repoLabels = %v, want bug", labels)
+	}
+	// 100, 50 and then 25
+	if got := len(f.calls("labels(first: $first")); got != 3 {
+		t.Errorf("labels query sent %d times, want 3", got)
+	}
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/machinebox/graphql"
+)
+
+// IsNotFound reports whether err is GitHub's "could not resolve" error for a missing (or
+// inaccessible) repository, issue or owner.
+func IsNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Could not resolve to a")
+}
+
+// CreateRepository creates owner/name with issues enabled and returns its node ID. When template
+// ("owner/repo") is set, the repository is generated from that template repository instead.
+func (c *Client) CreateRepository(ctx context.Context, owner, name, template string, public bool) (string, error) {
+	ownerID, err := c.resolveOwnerID(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	visibility := "PRIVATE"
+	if public {
+		visibility = "PUBLIC"
+	}
+
+	var req *graphql.Request
+	if template != "" {
+		templateOwner, templateRepo, ok := strings.Cut(template, "/")
+		if !ok || templateOwner == "" || templateRepo == "" {
+			return "", fmt.Errorf("invalid template %q: expected owner/repo", template)
+		}
+		templateID, err := c.ResolveRepositoryID(ctx, templateOwner, templateRepo)
+		if err != nil {
+			return "", fmt.Errorf("resolve template repository: %w", err)
+		}
+		req = graphql.NewRequest(`
+			mutation($input: CloneTemplateRepositoryInput!) {
+				cloneTemplateRepository(input: $input) {
+					repository { id }
+				}
+			}
+		`)
+		req.Var("input", map[string]interface{}{
+			"repositoryId": templateID,
+			"ownerId":      ownerID,
+			"name":         name,
+			"visibility":   visibility,
+		})
+	} else {
+		req = graphql.NewRequest(`
+			mutation($input: CreateRepositoryInput!) {
+				createRepository(input: $input) {
+					repository { id }
+				}
+			}
+		`)
+		req.Var("input", map[string]interface{}{
+			"ownerId":          ownerID,
+			"name":             name,
+			"visibility":       visibility,
+			"hasIssuesEnabled": true,
+		})
+	}
+
+	var out struct {
+		CreateRepository struct {
+			Repository struct {
+				ID string `json:"id"`
+			} `json:"repository"`
+		} `json:"createRepository"`
+		CloneTemplateRepository struct {
+			Repository struct {
+				ID string `json:"id"`
+			} `json:"repository"`
+		} `json:"cloneTemplateRepository"`
+	}
+	if err := c.runMutation(ctx, req, &out); err != nil {
+		return "", fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	id := out.CreateRepository.Repository.ID
+	if template != "" {
+		id = out.CloneTemplateRepository.Repository.ID
+	}
+	if id == "" {
+		return "", fmt.Errorf("repository id empty after creating %s/%s", owner, name)
+	}
+	c.cache.setRepoID(owner, name, id)
+	return id, nil
+}
+
+// resolveOwnerID resolves an organization or user login to its node ID.
+func (c *Client) resolveOwnerID(ctx context.Context, login string) (string, error) {
+	req := graphql.NewRequest(`
+		query($login: String!) {
+			repositoryOwner(login: $login) { id }
+		}
+	`)
+	req.Var("login", login)
+
+	var out struct {
+		RepositoryOwner *struct {
+			ID string `json:"id"`
+		} `json:"repositoryOwner"`
+	}
+	if err := c.run(ctx, req, &out); err != nil {
+		return "", fmt.Errorf("owner query failed: %w", err)
+	}
+	if out.RepositoryOwner == nil || out.RepositoryOwner.ID == "" {
+		return "", fmt.Errorf("owner %q not found", login)
+	}
+	return out.RepositoryOwner.ID, nil
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github-issue-manager/pkg/logger"
+
+	"github.com/machinebox/graphql"
+)
+
+// MaxRetries is how many times clients created by NewClient retry a single GraphQL call that hit a
+// rate limit or a 5xx response before giving up.
+var MaxRetries = 3
+
+// Backoff bounds for retried calls without a Retry-After header; jitter is added on top.
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = time.Minute
+)
+
+// MaxRetriesTotal bounds the retries of every client created by NewClient across its whole run;
+// 0 means unlimited. Once the budget is spent, failures that would be retried fail fast instead.
+var MaxRetriesTotal int
+
+// ErrRetryBudgetExhausted is wrapped into errors returned instead of retrying once the run's retry
+// budget is spent.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted (--max-retries-total)")
+
+// retryBudget counts the retries taken by a client.
+type retryBudget struct {
+	mu    sync.Mutex
+	limit int // 0 means unlimited
+	used  int
+}
+
+// take consumes one retry and reports whether it was available.
+func (b *retryBudget) take() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.limit > 0 && b.used >= b.limit {
+		return false
+	}
+	b.used++
+	return true
+}
+
+// RetriesUsed returns how many retries the client has taken and its total budget (0 if unlimited).
+func (c *Client) RetriesUsed() (used, limit int) {
+	if c.retries == nil {
+		return 0, 0
+	}
+	c.retries.mu.Lock()
+	defer c.retries.mu.Unlock()
+	return c.retries.used, c.retries.limit
+}
+
+// run runs a GraphQL query, retrying rate-limited (HTTP 429, secondary rate limit 403s and
+// RATE_LIMITED errors) and 5xx responses up to MaxRetries times. It waits for the Retry-After header
+// when GitHub sends one and backs off exponentially with jitter otherwise. Every retry counts
+// against the client's retry budget.
+func (c *Client) run(ctx context.Context, req *graphql.Request, resp interface{}) error {
+	return c.runRetrying(ctx, req, resp, isRetryableError)
+}
+
+// runMutation runs a GraphQL mutation like run, but only retries rate limits: a 5xx may come back
+// after GitHub already applied the mutation, and retrying it could create a duplicate issue,
+// comment or label.
+func (c *Client) runMutation(ctx context.Context, req *graphql.Request, resp interface{}) error {
+	return c.runRetrying(ctx, req, resp, isRateLimitError)
+}
+
+func (c *Client) runRetrying(ctx context.Context, req *graphql.Request, resp interface{}, retryable func(error) bool) error {
+	for attempt := 0; ; attempt++ {
+		err := c.GraphQL.Run(ctx, req, resp)
+		if err == nil || !retryable(err) || attempt >= MaxRetries || ctx.Err() != nil {
+			return err
+		}
+		if !c.retries.take() {
+			return fmt.Errorf("%w: %v", ErrRetryBudgetExhausted, err)
+		}
+
+		delay := retryDelay(err, attempt)
+		logger.Warn("GitHub call failed, retrying", "attempt", attempt+1, "maxRetries", MaxRetries, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isRetryableError reports whether err is a rate limit or a server-side failure worth retrying.
+func isRetryableError(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) && status.StatusCode >= 500 {
+		return true
+	}
+	return isRateLimitError(err)
+}
+
+// isRateLimitError reports whether err is a primary or secondary rate limit, which GitHub rejects
+// before doing any work.
+func isRateLimitError(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		switch status.StatusCode {
+		case http.StatusTooManyRequests:
+			return true
+		case http.StatusForbidden:
+			return status.Header.Get("Retry-After") != "" || isRateLimitMessage(status.Message)
+		}
+		return false
+	}
+	// The primary GraphQL rate limit is reported as a RATE_LIMITED error in a 200 response
+	return isRateLimitMessage(err.Error())
+}
+
+func isRateLimitMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "rate limit") || strings.Contains(message, "abuse detection")
+}
+
+// retryDelay returns how long to wait before retry number attempt (0-based): the Retry-After
+// header when the error carries one, otherwise an exponential backoff with up to 50% jitter.
+func retryDelay(err error, attempt int) time.Duration {
+	var status *statusError
+	if errors.As(err, &status) {
+		if after := status.Header.Get("Retry-After"); after != "" {
+			if seconds, parseErr := strconv.Atoi(after); parseErr == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+			if at, parseErr := http.ParseTime(after); parseErr == nil {
+				if delay := time.Until(at); delay > 0 {
+					return delay
+				}
+				return 0
+			}
+		}
+	}
+
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// failTimes answers the first n requests with status and the rest with data.
+func failTimes(n int, status fakeStatus, data interface{}) func(fakeRequest) interface{} {
+	calls := 0
+	return func(fakeRequest) interface{} {
+		calls++
+		if calls <= n {
+			return status
+		}
+		return data
+	}
+}
+
+var secondaryRateLimit = fakeStatus{
+	code:       http.StatusForbidden,
+	message:    "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+	retryAfter: "0",
+}
+
+func TestRunRetriesRateLimits(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("repository(owner", failTimes(2, secondaryRateLimit, obj{"repository": obj{"id": "R_1"}}))
+
+	id, err := c.ResolveRepositoryID(context.Background(), "octo", "hello")
+	if err != nil {
+		t.Fatalf("ResolveRepositoryID: %v", err)
+	}
+	if id != "R_1" {
+		t.Errorf("ResolveRepositoryID = %q, want R_1", id)
+	}
+	if got := len(f.calls("repository(owner")); got != 3 {
+		t.Errorf("repository query sent %d times, want 3", got)
+	}
+	if used, _ := c.RetriesUsed(); used != 2 {
+		t.Errorf("RetriesUsed = %d, want 2", used)
+	}
+}
+
+func TestRunMutationRetriesRateLimits(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("addComment", failTimes(2, secondaryRateLimit, obj{"addComment": obj{"commentEdge": obj{"node": obj{"id": "C_1"}}}}))
+
+	if err := c.AddComment(context.Background(), "I_1", "hello"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if got := len(f.calls("addComment")); got != 3 {
+		t.Errorf("addComment sent %d times, want 3", got)
+	}
+	if used, _ := c.RetriesUsed(); used != 2 {
+		t.Errorf("RetriesUsed = %d, want 2", used)
+	}
+}
+
+func TestRunStopsRetryingWhenBudgetIsSpent(t *testing.T) {
+	limit := MaxRetriesTotal
+	MaxRetriesTotal = 3
+	t.Cleanup(func() { MaxRetriesTotal = limit })
+
+	f, c := newFakeGitHub(t)
+	f.on("repository(owner", failTimes(2, secondaryRateLimit, obj{"repository": obj{"id": "R_1"}}))
+	f.on("addComment", failTimes(MaxRetries+1, secondaryRateLimit, obj{"addComment": obj{"commentEdge": obj{"node": obj{"id": "C_1"}}}}))
+
+	if _, err := c.ResolveRepositoryID(context.Background(), "octo", "hello"); err != nil {
+		t.Fatalf("ResolveRepositoryID: %v", err)
+	}
+	// One retry is left in the budget, so the comment gets a single retry instead of MaxRetries
+	err := c.AddComment(context.Background(), "I_1", "hello")
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("AddComment error = %v, want ErrRetryBudgetExhausted", err)
+	}
+	if got := len(f.calls("addComment")); got != 2 {
+		t.Errorf("addComment sent %d times, want 2", got)
+	}
+	if used, limit := c.RetriesUsed(); used != 3 || limit != 3 {
+		t.Errorf("RetriesUsed = %d of %d, want 3 of 3", used, limit)
+	}
+}
+
+func TestRunMutationDoesNotRetryServerErrors(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	badGateway := fakeStatus{code: http.StatusBadGateway, message: "Bad Gateway", retryAfter: "0"}
+	f.on("addComment", failTimes(1, badGateway, obj{"addComment": obj{"commentEdge": obj{"node": obj{"id": "C_1"}}}}))
+
+	if err := c.AddComment(context.Background(), "I_1", "hello"); err == nil {
+		t.Fatal("AddComment succeeded, want the 502")
+	}
+	if got := len(f.calls("addComment")); got != 1 {
+		t.Errorf("addComment sent %d times, want 1", got)
+	}
+}
+
+func TestRunRetriesServerErrorsForQueries(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	badGateway := fakeStatus{code: http.StatusBadGateway, message: "Bad Gateway", retryAfter: "0"}
+	f.on("repository(owner", failTimes(1, badGateway, obj{"repository": obj{"id": "R_1"}}))
+
+	id, err := c.ResolveRepositoryID(context.Background(), "octo", "hello")
+	if err != nil {
+		t.Fatalf("ResolveRepositoryID: %v", err)
+	}
+	if id != "R_1" {
+		t.Errorf("ResolveRepositoryID = %q, want R_1", id)
+	}
+	if got := len(f.calls("repository(owner")); got != 2 {
+		t.Errorf("repository query sent %d times, want 2", got)
+	}
+}
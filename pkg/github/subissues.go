@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/machinebox/graphql"
+)
+
+// SubIssues holds an issue's sub-issues on GitHub and its progress summary.
+type SubIssues struct {
+	Completed        int        `json:"completed"`
+	Total            int        `json:"total"`
+	PercentCompleted int        `json:"percentCompleted"`
+	Issues           []IssueRef `json:"issues"`
+}
+
+// GetSubIssues fetches the sub-issues of an issue and GitHub's completed/total summary for them.
+func (c *Client) GetSubIssues(ctx context.Context, owner, repo string, issueNumber int64) (*SubIssues, error) {
+	if issueNumber <= 0 {
+		return nil, fmt.Errorf("invalid issue number: %d", issueNumber)
+	}
+
+	result := &SubIssues{}
+	var after *string
+	pageSize := 100
+	for {
+		req := graphql.NewRequest(`
+			query($owner: String!, $name: String!, $number: Int!, $first: Int!, $after: String) {
+				repository(owner: $owner, name: $name) {
+					issue(number: $number) {
+						id
+						subIssuesSummary { completed total percentCompleted }
+						subIssues(first: $first, after: $after) {
+							pageInfo { hasNextPage endCursor }
+							nodes { id number title }
+						}
+					}
+				}
+			}
+		`)
+		req.Var("owner", owner)
+		req.Var("name", repo)
+		req.Var("number", int(issueNumber))
+		req.Var("after", after)
+
+		var out struct {
+			Repository struct {
+				Issue struct {
+					ID               string `json:"id"`
+					SubIssuesSummary struct {
+						Completed        int `json:"completed"`
+						Total            int `json:"total"`
+						PercentCompleted int `json:"percentCompleted"`
+					} `json:"subIssuesSummary"`
+					SubIssues struct {
+						PageInfo struct {
+							HasNextPage bool    `json:"hasNextPage"`
+							EndCursor   *string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []IssueRef `json:"nodes"`
+					} `json:"subIssues"`
+				} `json:"issue"`
+			} `json:"repository"`
+		}
+		if err := c.runPage(ctx, req, &pageSize, &out); err != nil {
+			return nil, fmt.Errorf("failed to query sub-issues via GraphQL: %w", err)
+		}
+
+		issue := out.Repository.Issue
+		if issue.ID == "" {
+			return nil, fmt.Errorf("issue #%d not found in %s/%s", issueNumber, owner, repo)
+		}
+		result.Completed = issue.SubIssuesSummary.Completed
+		result.Total = issue.SubIssuesSummary.Total
+		result.PercentCompleted = issue.SubIssuesSummary.PercentCompleted
+		result.Issues = append(result.Issues, issue.SubIssues.Nodes...)
+
+		pageInfo := issue.SubIssues.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			return result, nil
+		}
+		after = pageInfo.EndCursor
+	}
+}
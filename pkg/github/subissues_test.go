@@ -0,0 +1,28 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSubIssuesFollowsPages(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.on("subIssues(first", pagedNodes(
+		[]obj{{"id": "I_2", "number": 2}, {"id": "I_3", "number": 3}, {"id": "I_4", "number": 4}},
+		func(subIssues obj) obj {
+			return obj{"repository": obj{"issue": obj{
+				"id":               "I_1",
+				"subIssuesSummary": obj{"completed": 1, "total": 3, "percentCompleted": 33},
+				"subIssues":        subIssues,
+			}}}
+		},
+	))
+
+	got, err := c.GetSubIssues(context.Background(), "octo", "hello", 1)
+	if err != nil {
+		t.Fatalf("GetSubIssues: %v", err)
+	}
+	if len(got.Issues) != 3 || got.Issues[2].Number != 4 || got.Total != 3 {
+		t.Errorf("GetSubIssues = %+v, want all 3 sub-issues", got)
+	}
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github-issue-manager/pkg/logger"
+)
+
+// httpClient is the HTTP client used by clients created by NewClient; nil means http.DefaultClient.
+var httpClient *http.Client
+
+// authTransport sets the Authorization header of every request to the GitHub token and turns
+// error statuses into a *statusError.
+type authTransport struct {
+	token string            // empty means look the token up with getToken on each request
+	base  http.RoundTripper // nil means http.DefaultTransport
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.token
+	if token == "" {
+		var err error
+		if token, err = getToken(); err != nil {
+			return nil, fmt.Errorf("failed to get GitHub token: %w", err)
+		}
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newStatusError(resp)
+	}
+	return resp, nil
+}
+
+// statusError is returned for HTTP responses GitHub answers with an error status. The GraphQL client
+// would otherwise decode their {"message": ...} body as an empty, successful response.
+type statusError struct {
+	StatusCode int
+	Message    string
+	Header     http.Header
+}
+
+func (e *statusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub returned HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub returned HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// newStatusError builds a statusError from an error response, closing its body.
+func newStatusError(resp *http.Response) *statusError {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var payload struct {
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &payload) == nil && payload.Message != "" {
+		message = payload.Message
+	}
+	return &statusError{StatusCode: resp.StatusCode, Message: message, Header: resp.Header}
+}
+
+// ConfigureTLS sets up TLS for clients created by NewClient. caFile adds a PEM CA bundle to the
+// system roots (for self-signed GitHub Enterprise certificates); insecureSkipVerify disables
+// certificate verification entirely and should only be a last resort.
+func ConfigureTLS(insecureSkipVerify bool, caFile string) error {
+	if !insecureSkipVerify && caFile == "" {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in CA file %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if insecureSkipVerify {
+		logger.Warn("TLS certificate verification is DISABLED (--insecure-skip-verify); traffic to GitHub can be intercepted")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient = &http.Client{Transport: transport}
+	return nil
+}
@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestsCarryAuthorizationHeader(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	tests := []struct {
+		name string
+		pat  string
+		want string
+	}{
+		{name: "token passed to NewClient", pat: "test-token", want: "Bearer test-token"},
+		{name: "token from the environment", pat: "", want: "Bearer env-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, _ := newFakeGitHub(t)
+			f.reply("repository(owner", obj{"repository": obj{"id": "R_1"}})
+
+			c := NewClient(context.Background(), tt.pat)
+			if _, err := c.ResolveRepositoryID(context.Background(), "octo", "hello"); err != nil {
+				t.Fatalf("ResolveRepositoryID: %v", err)
+			}
+			if got := f.calls("repository(owner")[0].Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package issuemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadBatchFile reads a YAML or JSON file holding a list of issues. Entries use the same keys as
+// issue front matter plus body; the ListFrontMatterKeys may be a comma-separated string or a list,
+// and project_fields a "Field=Option; ..." string or a mapping. Each issue's BatchIndex is its
+// position in the file.
+func ReadBatchFile(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so one decoder handles both formats
+	var entries []map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse batch file %s: %w", path, err)
+	}
+
+	issues := make([]Issue, 0, len(entries))
+	for i, entry := range entries {
+		issue, err := batchEntryIssue(entry)
+		if err != nil {
+			return nil, fmt.Errorf("parse batch file %s: entry %d: %w", path, i+1, err)
+		}
+		issue.BatchIndex = i
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// batchEntryIssue converts a batch file entry into an Issue.
+func batchEntryIssue(entry map[string]yaml.Node) (Issue, error) {
+	frontMatter := make(map[string]string)
+	for key, node := range entry {
+		if containsFold(ListFrontMatterKeys, key) {
+			frontMatter[key] = strings.Join(ListValues(&node), ", ")
+		} else if node.Kind == yaml.ScalarNode {
+			frontMatter[key] = strings.TrimSpace(node.Value)
+		}
+	}
+
+	owner, repo := ParseRepoTarget(frontMatter["repo"])
+	issue := Issue{
+		Title:   frontMatter["title"],
+		Body:    frontMatter["body"],
+		Labels:  SplitLabels(frontMatter["labels"]),
+		Type:    frontMatter["type"],
+		Id:      frontMatter["id"],
+		Project: frontMatter["project"],
+		Parent:  frontMatter["parent"],
+		Owner:   owner,
+
+		Assignees: SplitLabels(frontMatter["assignees"]),
+		Projects:  SplitLabels(frontMatter["projects"]),
+		DependsOn: SplitLabels(frontMatter["depends_on"]),
+		Repo:      repo,
+
+		ExternalID:    frontMatter["external_id"],
+		ProjectFields: ParseProjectFields(frontMatter["project_fields"]),
+		FrontMatter:   frontMatter,
+	}
+
+	if node, ok := entry["labels"]; ok && node.Kind == yaml.SequenceNode {
+		if err := node.Decode(&issue.LabelDefinitions); err != nil {
+			return Issue{}, fmt.Errorf("labels: %w", err)
+		}
+	}
+
+	if node, ok := entry["project_fields"]; ok && node.Kind == yaml.MappingNode {
+		issue.ProjectFields = ProjectFieldsFromNode(&node)
+	}
+	issue.ProjectFields = withStatus(issue.ProjectFields, frontMatter["status"])
+
+	return issue, nil
+}
+
+// WriteBatchIDs sets the id of the batch file entries at the given indexes, updating the file in
+// place. YAML files keep their comments and key order; JSON files are rewritten with sorted keys.
+func WriteBatchIDs(path string, ids map[int]int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var entries []map[string]interface{}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return fmt.Errorf("parse batch file %s: %w", path, err)
+		}
+		for index, number := range ids {
+			if index < 0 || index >= len(entries) {
+				return fmt.Errorf("batch file %s has no entry %d", path, index+1)
+			}
+			entries[index]["id"] = number
+		}
+		out, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, append(out, '\n'), 0644)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse batch file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+		return fmt.Errorf("batch file %s is not a list of issues", path)
+	}
+	list := doc.Content[0]
+	for index, number := range ids {
+		if index < 0 || index >= len(list.Content) || list.Content[index].Kind != yaml.MappingNode {
+			return fmt.Errorf("batch file %s has no entry %d", path, index+1)
+		}
+		setMappingValue(list.Content[index], "id", strconv.FormatInt(number, 10))
+	}
+
+	var b strings.Builder
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// setMappingValue sets key to an integer scalar value in a YAML mapping, appending the key if missing.
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].Kind = yaml.ScalarNode
+			mapping.Content[i+1].Tag = "!!int"
+			mapping.Content[i+1].Value = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value},
+	)
+}
@@ -0,0 +1,146 @@
+package issuemanager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	mdparser "github-issue-manager/pkg/mdparser"
+)
+
+// BodyTemplateData is what a body template is rendered with.
+type BodyTemplateData struct {
+	Title  string
+	Type   string
+	Labels []string
+	Parent string
+	// Body is the markdown written below the front matter, so templates can keep authored text
+	Body string
+	// Fields holds every front matter value; lists (e.g. x-repro-steps) are []interface{}
+	Fields map[string]interface{}
+}
+
+// ApplyBodyTemplates renders the body of every issue whose type has a template in dir, named after
+// the type in lower case (e.g. bug.md.tmpl), from the issue's front matter. Issues of other types
+// keep their body, and so do issues with a body written in the file unless overwrite is set (the
+// template can then include it as .Body). Templates can use {{field "key"}}, {{range list "key"}}
+// and {{add a b}}.
+func ApplyBodyTemplates(issues []Issue, dir string, overwrite bool) error {
+	templates := make(map[string]*template.Template)
+	for i := range issues {
+		typeName := strings.ToLower(strings.TrimSpace(issues[i].Type))
+		if typeName == "" || (!overwrite && strings.TrimSpace(issues[i].Body) != "") {
+			continue
+		}
+
+		tmpl, ok := templates[typeName]
+		if !ok {
+			var err error
+			if tmpl, err = loadBodyTemplate(filepath.Join(dir, typeName+".md.tmpl")); err != nil {
+				return err
+			}
+			templates[typeName] = tmpl
+		}
+		if tmpl == nil {
+			continue
+		}
+
+		fields, err := templateFields(issues[i])
+		if err != nil {
+			return err
+		}
+		data := BodyTemplateData{
+			Title:  issues[i].Title,
+			Type:   issues[i].Type,
+			Labels: issues[i].Labels,
+			Parent: issues[i].Parent,
+			Body:   strings.TrimSpace(issues[i].Body),
+			Fields: fields,
+		}
+
+		var out bytes.Buffer
+		if err := tmpl.Funcs(bodyTemplateFuncs(fields)).Execute(&out, data); err != nil {
+			return fmt.Errorf("render %s body template for %s: %w", typeName, describe(issues[i]), err)
+		}
+		issues[i].Body = strings.TrimSpace(out.String())
+	}
+	return nil
+}
+
+// loadBodyTemplate parses the template at path, returning nil if there is none.
+func loadBodyTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(bodyTemplateFuncs(nil)).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse body template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+func bodyTemplateFuncs(fields map[string]interface{}) template.FuncMap {
+	return template.FuncMap{
+		"add": func(a, b int) int { return a + b },
+		"field": func(key string) string {
+			if value, ok := fields[key]; ok && value != nil {
+				return strings.TrimSpace(fmt.Sprint(value))
+			}
+			return ""
+		},
+		"list": func(key string) []string {
+			switch value := fields[key].(type) {
+			case []interface{}:
+				items := make([]string, 0, len(value))
+				for _, item := range value {
+					items = append(items, fmt.Sprint(item))
+				}
+				return items
+			case string:
+				return SplitLabels(value)
+			}
+			return nil
+		},
+	}
+}
+
+// templateFields returns the front matter of an issue with lists intact, falling back to the flat
+// values when the file's front matter isn't valid YAML or the issue has no file.
+func templateFields(issue Issue) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(issue.FrontMatter))
+	for key, value := range issue.FrontMatter {
+		if key != "body" {
+			fields[key] = value
+		}
+	}
+	if issue.FileName == "" {
+		return fields, nil
+	}
+
+	_, nodes, err := mdparser.ReadFrontMatter(filepath.Join(issue.Path, issue.FileName))
+	if err != nil {
+		return fields, err
+	}
+	for key, node := range nodes {
+		var value interface{}
+		if node.Decode(&value) == nil {
+			fields[key] = value
+		}
+	}
+	return fields, nil
+}
+
+func describe(issue Issue) string {
+	if issue.FileName != "" {
+		return filepath.Join(issue.Path, issue.FileName)
+	}
+	return issue.Title
+}
@@ -0,0 +1,41 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyBodyTemplates(t *testing.T) {
+	dir := t.TempDir()
+	template := "Severity: {{field \"severity\"}}\n{{range list \"steps\"}}- {{.}}\n{{end}}{{.Body}}"
+	if err := os.WriteFile(filepath.Join(dir, "bug.md.tmpl"), []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]string{"severity": "high", "steps": "open, click"}
+
+	tests := []struct {
+		name      string
+		issue     Issue
+		overwrite bool
+		want      string
+	}{
+		{name: "empty body rendered", issue: Issue{Type: "Bug", FrontMatter: fields}, want: "Severity: high\n- open\n- click"},
+		{name: "written body kept", issue: Issue{Type: "Bug", Body: "Written by hand", FrontMatter: fields}, want: "Written by hand"},
+		{name: "whitespace-only body rendered", issue: Issue{Type: "bug", Body: "\n  \n", FrontMatter: fields}, want: "Severity: high\n- open\n- click"},
+		{name: "written body overwritten", issue: Issue{Type: "Bug", Body: "Written by hand", FrontMatter: fields}, overwrite: true, want: "Severity: high\n- open\n- click\nWritten by hand"},
+		{name: "type without a template", issue: Issue{Type: "Task", FrontMatter: fields}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := []Issue{tt.issue}
+			if err := ApplyBodyTemplates(issues, dir, tt.overwrite); err != nil {
+				t.Fatalf("ApplyBodyTemplates: %v", err)
+			}
+			if issues[0].Body != tt.want {
+				t.Errorf("body = %q, want %q", issues[0].Body, tt.want)
+			}
+		})
+	}
+}
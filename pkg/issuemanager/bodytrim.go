@@ -0,0 +1,55 @@
+package issuemanager
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BodyTrim controls how much whitespace is trimmed from an issue body read from a file.
+type BodyTrim string
+
+const (
+	// BodyTrimNone keeps the body exactly as written below the front matter.
+	BodyTrimNone BodyTrim = "none"
+	// BodyTrimEdges removes leading and trailing blank lines, preserving everything in between.
+	BodyTrimEdges BodyTrim = "edges"
+	// BodyTrimAll also strips the surrounding whitespace of every line, including indentation.
+	BodyTrimAll BodyTrim = "all"
+)
+
+// ParseBodyTrim validates a body trim policy name, defaulting to BodyTrimEdges when empty.
+func ParseBodyTrim(value string) (BodyTrim, error) {
+	switch BodyTrim(strings.ToLower(strings.TrimSpace(value))) {
+	case "", BodyTrimEdges:
+		return BodyTrimEdges, nil
+	case BodyTrimNone:
+		return BodyTrimNone, nil
+	case BodyTrimAll:
+		return BodyTrimAll, nil
+	default:
+		return "", fmt.Errorf("invalid body trim policy %q (expected none, edges or all)", value)
+	}
+}
+
+// TrimBody applies the trim policy to body. An empty policy behaves like BodyTrimEdges.
+func TrimBody(body string, policy BodyTrim) string {
+	if policy == BodyTrimNone {
+		return body
+	}
+
+	lines := strings.Split(body, "\n")
+	if policy == BodyTrimAll {
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+	}
+
+	start, end := 0, len(lines)
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return strings.Join(lines[start:end], "\n")
+}
@@ -0,0 +1,53 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrimBody(t *testing.T) {
+	body := "\n  \nSteps:\n\n    go test ./...\n\tindented\n\n \n"
+	tests := []struct {
+		policy BodyTrim
+		want   string
+	}{
+		{BodyTrimNone, body},
+		{BodyTrimEdges, "Steps:\n\n    go test ./...\n\tindented"},
+		{"", "Steps:\n\n    go test ./...\n\tindented"},
+		{BodyTrimAll, "Steps:\n\ngo test ./...\nindented"},
+	}
+	for _, tt := range tests {
+		if got := TrimBody(body, tt.policy); got != tt.want {
+			t.Errorf("TrimBody(%q) = %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestParseBodyTrim(t *testing.T) {
+	for value, want := range map[string]BodyTrim{"": BodyTrimEdges, "Edges": BodyTrimEdges, " none ": BodyTrimNone, "all": BodyTrimAll} {
+		if got, err := ParseBodyTrim(value); err != nil || got != want {
+			t.Errorf("ParseBodyTrim(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseBodyTrim("some"); err == nil {
+		t.Error("ParseBodyTrim(\"some\") succeeded, want an error")
+	}
+}
+
+func TestReadIssueFileKeepsIndentedCode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issue.md")
+	content := "---\ntitle: T\n---\n\nRun:\n\n    go test ./...\n\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue, err := ReadIssueFile(path, "", BodyTrimEdges)
+	if err != nil {
+		t.Fatalf("ReadIssueFile: %v", err)
+	}
+	if want := "Run:\n\n    go test ./..."; issue.Body != want {
+		t.Errorf("Body = %q, want %q", issue.Body, want)
+	}
+}
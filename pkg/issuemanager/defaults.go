@@ -0,0 +1,111 @@
+package issuemanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultsFileName is the file in an issue folder whose front matter values apply to every issue in it.
+const DefaultsFileName = "defaults.yaml"
+
+// ReadDefaults reads the defaults file of an issue folder. Values may be scalars or lists (joined
+// with ", " like a comma-separated labels value). Keys must be front matter keys the tool reads or
+// custom x-/_ keys. A folder without a defaults file has no defaults.
+func ReadDefaults(dir string) (map[string]string, error) {
+	path := filepath.Join(dir, DefaultsFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse defaults file %s: %w", path, err)
+	}
+
+	defaults := make(map[string]string, len(raw))
+	for key, node := range raw {
+		switch strings.ToLower(key) {
+		case "id", "title", "external_id":
+			return nil, fmt.Errorf("parse defaults file %s: %s identifies a single issue and can't have a default", path, key)
+		}
+		// A default for a key nothing reads (e.g. milestone) would be silently dropped
+		if unknown := UnknownFrontMatterKeys(map[string]string{key: ""}); len(unknown) > 0 {
+			return nil, fmt.Errorf("parse defaults file %s: %s is not a front matter key the tool reads (prefix custom keys with x- or _)", path, key)
+		}
+		switch node.Kind {
+		case yaml.ScalarNode:
+			defaults[strings.ToLower(key)] = strings.TrimSpace(node.Value)
+		case yaml.SequenceNode:
+			var values []string
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("parse defaults file %s: %s: list entries must be plain values", path, key)
+				}
+				values = append(values, strings.TrimSpace(item.Value))
+			}
+			defaults[strings.ToLower(key)] = strings.Join(values, ", ")
+		default:
+			return nil, fmt.Errorf("parse defaults file %s: %s: expected a value or a list", path, key)
+		}
+	}
+	return defaults, nil
+}
+
+// applyDefaults merges folder defaults into a file's front matter. A value set in the file replaces
+// the default, except for labels, where the file's labels are added to the default labels.
+func applyDefaults(frontMatter, defaults map[string]string) {
+	for key, value := range defaults {
+		current := strings.TrimSpace(frontMatter[key])
+		switch {
+		case key == "labels" && current != "":
+			merged := SplitLabels(value)
+			for _, label := range SplitLabels(current) {
+				if !containsFold(merged, label) {
+					merged = append(merged, label)
+				}
+			}
+			frontMatter[key] = strings.Join(merged, ", ")
+		case current == "":
+			frontMatter[key] = value
+		}
+	}
+}
+
+// ReadDefaultsChain returns the defaults for issue files in dir, a folder at or below root: the
+// defaults files of root and of every folder down to dir are merged, with a nearer folder's value
+// replacing an outer one except for labels, which are combined like a file's labels are.
+func ReadDefaultsChain(root, dir string) (map[string]string, error) {
+	root, dir = filepath.Clean(root), filepath.Clean(dir)
+	folders := []string{dir}
+	if rel, err := filepath.Rel(root, dir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		folders = []string{root}
+		current := root
+		for _, part := range strings.Split(rel, string(filepath.Separator)) {
+			current = filepath.Join(current, part)
+			folders = append(folders, current)
+		}
+	}
+
+	var merged map[string]string
+	for _, folder := range folders {
+		defaults, err := ReadDefaults(folder)
+		if err != nil {
+			return nil, err
+		}
+		if defaults == nil {
+			continue
+		}
+		applyDefaults(defaults, merged)
+		merged = defaults
+	}
+	return merged, nil
+}
@@ -0,0 +1,88 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTree writes files, given as slash-separated paths relative to root and their content.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadDefaultsChain(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"defaults.yaml":              "project: Roadmap\ntype: Task\nlabels: [backend, team-auth]\n",
+		"bugs/defaults.yaml":         "type: Bug\nlabels: [bug, Backend]\n",
+		"bugs/ui/defaults.yaml":      "assignees: octocat\n",
+		"features/big/defaults.yaml": "project: Q4\n",
+	})
+
+	tests := []struct {
+		dir  string
+		want map[string]string
+	}{
+		{dir: ".", want: map[string]string{"project": "Roadmap", "type": "Task", "labels": "backend, team-auth"}},
+		{dir: "bugs", want: map[string]string{"project": "Roadmap", "type": "Bug", "labels": "backend, team-auth, bug"}},
+		{dir: "bugs/ui", want: map[string]string{"project": "Roadmap", "type": "Bug", "labels": "backend, team-auth, bug", "assignees": "octocat"}},
+		{dir: "features/big", want: map[string]string{"project": "Q4", "type": "Task", "labels": "backend, team-auth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			got, err := ReadDefaultsChain(root, filepath.Join(root, filepath.FromSlash(tt.dir)))
+			if err != nil {
+				t.Fatalf("ReadDefaultsChain: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadDefaultsChain = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDefaultsRejectsKeys(t *testing.T) {
+	for _, content := range []string{"title: Shared\n", "milestone: v1.0\n", "severity: High\n"} {
+		dir := t.TempDir()
+		writeTree(t, dir, map[string]string{DefaultsFileName: content})
+		key, _, _ := strings.Cut(content, ":")
+		if _, err := ReadDefaults(dir); err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("ReadDefaults(%q) error = %v, want one naming %s", content, err, key)
+		}
+	}
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{DefaultsFileName: "x-team: payments\n"})
+	if got, err := ReadDefaults(dir); err != nil || got["x-team"] != "payments" {
+		t.Errorf("ReadDefaults = %v, %v, want x-team kept", got, err)
+	}
+}
+
+func TestDefaultLabelsComeFirstWithoutDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		DefaultsFileName: "labels: [backend, team-auth]\n",
+		"issue.md":       "---\nlabels: [urgent, Backend, bug]\n---\n",
+	})
+
+	issue, err := ReadIssueFile(filepath.Join(dir, "issue.md"), "", BodyTrimEdges)
+	if err != nil {
+		t.Fatalf("ReadIssueFile: %v", err)
+	}
+	if want := []string{"backend", "team-auth", "urgent", "bug"}; !reflect.DeepEqual(issue.Labels, want) {
+		t.Errorf("Labels = %v, want %v", issue.Labels, want)
+	}
+}
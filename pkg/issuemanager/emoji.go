@@ -0,0 +1,100 @@
+package issuemanager
+
+import "regexp"
+
+// emojiShortcode matches GitHub emoji shortcodes such as :rocket: or :+1:.
+var emojiShortcode = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+
+// emojiShortcodes maps the GitHub shortcodes most used in issue titles and bodies to unicode.
+var emojiShortcodes = map[string]string{
+	"+1":                       "👍",
+	"-1":                       "👎",
+	"thumbsup":                 "👍",
+	"thumbsdown":               "👎",
+	"rocket":                   "🚀",
+	"bug":                      "🐛",
+	"sparkles":                 "✨",
+	"fire":                     "🔥",
+	"tada":                     "🎉",
+	"warning":                  "⚠️",
+	"rotating_light":           "🚨",
+	"construction":             "🚧",
+	"white_check_mark":         "✅",
+	"heavy_check_mark":         "✔️",
+	"x":                        "❌",
+	"memo":                     "📝",
+	"pencil2":                  "✏️",
+	"book":                     "📖",
+	"books":                    "📚",
+	"wrench":                   "🔧",
+	"hammer":                   "🔨",
+	"gear":                     "⚙️",
+	"lock":                     "🔒",
+	"unlock":                   "🔓",
+	"key":                      "🔑",
+	"zap":                      "⚡",
+	"boom":                     "💥",
+	"art":                      "🎨",
+	"lipstick":                 "💄",
+	"recycle":                  "♻️",
+	"truck":                    "🚚",
+	"package":                  "📦",
+	"arrow_up":                 "⬆️",
+	"arrow_down":               "⬇️",
+	"chart_with_upwards_trend": "📈",
+	"mag":                      "🔍",
+	"bulb":                     "💡",
+	"question":                 "❓",
+	"exclamation":              "❗",
+	"heavy_plus_sign":          "➕",
+	"heavy_minus_sign":         "➖",
+	"lady_beetle":              "🐞",
+	"ambulance":                "🚑",
+	"green_heart":              "💚",
+	"heart":                    "❤️",
+	"star":                     "⭐",
+	"eyes":                     "👀",
+	"clock":                    "🕐",
+	"hourglass":                "⌛",
+	"calendar":                 "📅",
+	"pushpin":                  "📌",
+	"link":                     "🔗",
+	"test_tube":                "🧪",
+	"globe_with_meridians":     "🌐",
+	"iphone":                   "📱",
+	"computer":                 "💻",
+	"whale":                    "🐳",
+	"penguin":                  "🐧",
+	"apple":                    "🍎",
+	"checkered_flag":           "🏁",
+	"triangular_flag_on_post":  "🚩",
+	"no_entry":                 "⛔",
+	"stop_sign":                "🛑",
+	"speech_balloon":           "💬",
+	"mega":                     "📣",
+	"loudspeaker":              "📢",
+	"card_file_box":            "🗃️",
+	"wastebasket":              "🗑️",
+	"label":                    "🏷️",
+	"children_crossing":        "🚸",
+	"wheelchair":               "♿",
+	"alien":                    "👽",
+	"money_with_wings":         "💸",
+	"building_construction":    "🏗️",
+	"see_no_evil":              "🙈",
+	"smile":                    "😄",
+	"joy":                      "😂",
+	"thinking":                 "🤔",
+	"100":                      "💯",
+}
+
+// ExpandEmoji replaces known GitHub emoji shortcodes in s with their unicode characters for display.
+// Unknown shortcodes are left as they are.
+func ExpandEmoji(s string) string {
+	return emojiShortcode.ReplaceAllStringFunc(s, func(code string) string {
+		if emoji, ok := emojiShortcodes[code[1:len(code)-1]]; ok {
+			return emoji
+		}
+		return code
+	})
+}
@@ -0,0 +1,25 @@
+package issuemanager
+
+import "testing"
+
+func TestExpandEmoji(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "known shortcode", in: ":bug: Login fails", want: "🐛 Login fails"},
+		{name: "adjacent shortcodes", in: ":rocket::bug:", want: "🚀🐛"},
+		{name: "unknown shortcode", in: "Ship :not_an_emoji: today", want: "Ship :not_an_emoji: today"},
+		{name: "time of day", in: "Deploy at 10:30:45 UTC", want: "Deploy at 10:30:45 UTC"},
+		{name: "no shortcode", in: "Plain title", want: "Plain title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandEmoji(tt.in); got != tt.want {
+				t.Errorf("ExpandEmoji(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
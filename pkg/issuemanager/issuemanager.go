@@ -1,13 +1,41 @@
 package issuemanager
 
 import (
+	"fmt"
 	"github-issue-manager/pkg/logger"
 	mdparser "github-issue-manager/pkg/mdparser"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IDPosition controls where a new id line is inserted into the front matter.
+type IDPosition string
+
+const (
+	// IDPositionFirst inserts the id right after the opening front matter fence.
+	IDPositionFirst IDPosition = "first"
+	// IDPositionLast inserts the id right before the closing front matter fence.
+	IDPositionLast IDPosition = "last"
 )
 
+// ParseIDPosition validates an id position name, defaulting to IDPositionLast when empty.
+func ParseIDPosition(value string) (IDPosition, error) {
+	switch IDPosition(strings.ToLower(strings.TrimSpace(value))) {
+	case "", IDPositionLast:
+		return IDPositionLast, nil
+	case IDPositionFirst:
+		return IDPositionFirst, nil
+	default:
+		return "", fmt.Errorf("invalid id position %q (expected first or last)", value)
+	}
+}
+
 // Issue represents the configuration for a GitHub issue.
 type Issue struct {
 	Path     string
@@ -19,55 +47,558 @@ type Issue struct {
 	Id       string
 	Project  string
 	Parent   string // Parent issue title for hierarchical relationships
+
+	// Assignees holds the logins of the users the issue is assigned to
+	Assignees []string
+
+	// Projects and DependsOn hold the multi-value projects and depends_on keys (further project
+	// titles and the titles of issues this one depends on). They are parsed like labels but create
+	// doesn't act on them yet.
+	Projects  []string
+	DependsOn []string
+
+	// ExternalID is a migration key embedded in the body as a hidden marker, so the issue can be
+	// found again even if its id is lost
+	ExternalID string
+
+	// BatchIndex is the position of the issue in its batch file (see ReadBatchFile)
+	BatchIndex int
+
+	// Owner and Repo override the run's target repository when set via the "repo" front matter key
+	Owner string
+	Repo  string
+
+	// LabelDefinitions holds the color and description of labels given in the structured list form,
+	// used when a missing label is created
+	LabelDefinitions []LabelDefinition
+
+	// ProjectFields holds single-select project field assignments applied after adding the issue to its project
+	ProjectFields []ProjectFieldValue
+
+	// FrontMatter holds the raw front matter key/value pairs the issue was read from
+	FrontMatter map[string]string
+}
+
+// Target returns the repository the issue belongs to, falling back to the given defaults.
+func (i Issue) Target(defaultOwner, defaultRepo string) (owner, repo string) {
+	owner, repo = defaultOwner, defaultRepo
+	if i.Owner != "" {
+		owner = i.Owner
+	}
+	if i.Repo != "" {
+		repo = i.Repo
+	}
+	return owner, repo
+}
+
+// ParseRepoTarget parses a "repo" front matter value, which is either "owner/name" or just "name"
+// (keeping the run's owner).
+func ParseRepoTarget(value string) (owner, repo string) {
+	value = strings.TrimSpace(value)
+	if o, r, ok := strings.Cut(value, "/"); ok {
+		return strings.TrimSpace(o), strings.TrimSpace(r)
+	}
+	return "", value
+}
+
+// ProjectFieldValue assigns a value to a project field (by name): an option name for single-select
+// fields, an iteration title for iteration fields, or a date, text or number.
+type ProjectFieldValue struct {
+	Field string
+	Value string
+}
+
+// ParseProjectFields parses a "Field=Option; Other Field=Option" list of project field assignments.
+// Segments without an "=" are ignored with a warning.
+func ParseProjectFields(value string) []ProjectFieldValue {
+	var fields []ProjectFieldValue
+	for _, part := range strings.Split(value, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		field, option, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(field) == "" {
+			logger.Warn("Ignoring malformed project field assignment (expected Field=Option)", "value", part)
+			continue
+		}
+		fields = append(fields, ProjectFieldValue{Field: strings.TrimSpace(field), Value: strings.TrimSpace(option)})
+	}
+	return fields
+}
+
+// ProjectFieldsFromNode reads project field assignments given as a YAML mapping of field name to
+// value, e.g. { Sprint: "Sprint 5", "Target Date": 2024-06-01 }, in the order written.
+func ProjectFieldsFromNode(node *yaml.Node) []ProjectFieldValue {
+	var fields []ProjectFieldValue
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fields = append(fields, ProjectFieldValue{
+			Field: strings.TrimSpace(node.Content[i].Value),
+			Value: strings.TrimSpace(node.Content[i+1].Value),
+		})
+	}
+	return fields
+}
+
+// projectFields returns the project field assignments of a file, whose project_fields value is
+// either a "Field=Value; ..." string or a YAML mapping, given its flat front matter and YAML nodes.
+func projectFields(nodes map[string]*yaml.Node, frontMatter map[string]string) []ProjectFieldValue {
+	if node, ok := nodes["project_fields"]; ok && node.Kind == yaml.MappingNode {
+		return ProjectFieldsFromNode(node)
+	}
+	return ParseProjectFields(frontMatter["project_fields"])
+}
+
+// withStatus adds the top-level status: value to fields as an assignment of the project's Status
+// field, unless fields already assign Status (project_fields wins).
+func withStatus(fields []ProjectFieldValue, status string) []ProjectFieldValue {
+	status = strings.TrimSpace(status)
+	if status == "" {
+		return fields
+	}
+	for _, field := range fields {
+		if strings.EqualFold(strings.TrimSpace(field.Field), "status") {
+			return fields
+		}
+	}
+	return append(fields, ProjectFieldValue{Field: "Status", Value: status})
+}
+
+// KnownFrontMatterKeys lists the front matter keys understood by the tool. The bug and development
+// sections written by the examples command (repro steps, severity, ...) are body text, not keys.
+var KnownFrontMatterKeys = []string{
+	"title", "labels", "assignees", "type", "id", "project", "parent", "status", "project_fields", "repo", "external_id",
+	"projects", "depends_on",
+	// Snapshot fields recorded from GitHub; read-only, never sent back
+	"state", "closed", "closed_at", "created_at", "updated_at", "author",
+}
+
+// UnknownFrontMatterKeys returns the sorted front matter keys that are not in KnownFrontMatterKeys.
+// Keys prefixed with "x-" or "_" are treated as intentional custom keys and never reported.
+func UnknownFrontMatterKeys(frontMatter map[string]string) []string {
+	known := make(map[string]bool, len(KnownFrontMatterKeys))
+	for _, key := range KnownFrontMatterKeys {
+		known[key] = true
+	}
+
+	var unknown []string
+	for key := range frontMatter {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if normalized == "body" || known[normalized] {
+			continue
+		}
+		if strings.HasPrefix(normalized, "x-") || strings.HasPrefix(normalized, "_") {
+			continue
+		}
+		// List items containing a colon are split into bogus keys by the line-based parser
+		if strings.HasPrefix(normalized, "-") {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// MissingRequiredFields returns the keys from the required-field policy for the issue's type that
+// the issue's front matter leaves unset or empty. Policy types are matched case-insensitively.
+func MissingRequiredFields(issue Issue, policy map[string][]string) []string {
+	issueType := strings.TrimSpace(issue.Type)
+	if issueType == "" {
+		return nil
+	}
+
+	var missing []string
+	for policyType, fields := range policy {
+		if !strings.EqualFold(strings.TrimSpace(policyType), issueType) {
+			continue
+		}
+		for _, field := range fields {
+			if strings.TrimSpace(issue.FrontMatter[field]) == "" {
+				missing = append(missing, field)
+			}
+		}
+	}
+	return missing
 }
 
 // ReadIssueFiles reads markdown files from the specified directory and extracts issue information.
 func ReadIssueFiles(dir string) ([]Issue, error) {
+	return ReadIssueFilesWithOptions(dir, ReadOptions{Workers: 1})
+}
+
+// ReadOptions controls how ReadIssueFilesWithOptions finds and parses issue files.
+type ReadOptions struct {
+	// Workers is the number of files parsed concurrently (at least 1).
+	Workers int
+	// Recursive also reads issue files in subdirectories, e.g. a folder organized by type.
+	Recursive bool
+	// BodyTrim is the whitespace trimming applied to bodies (BodyTrimEdges when empty).
+	BodyTrim BodyTrim
+}
+
+// ReadIssueFilesWithOptions is ReadIssueFiles with the given options. The result is sorted by path
+// regardless of the order in which files finish parsing.
+func ReadIssueFilesWithOptions(dir string, opts ReadOptions) ([]Issue, error) {
+	type issueFile struct{ dir, name string }
+	var files []issueFile
+	if opts.Recursive {
+		// Same file set as list --recursive
+		paths, err := mdparser.ListMarkdownFilesRecursive(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			files = append(files, issueFile{filepath.Dir(path), filepath.Base(path)})
+		}
+	} else {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+				files = append(files, issueFile{dir, entry.Name()})
+			}
+		}
+	}
+
+	// Each folder's issues get the defaults merged from dir down to that folder
+	defaults := make(map[string]map[string]string)
+	for _, file := range files {
+		if _, ok := defaults[file.dir]; ok {
+			continue
+		}
+		folderDefaults, err := ReadDefaultsChain(dir, file.dir)
+		if err != nil {
+			return nil, err
+		}
+		defaults[file.dir] = folderDefaults
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	parsed := make([]*Issue, len(files))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				issue, err := readIssueFile(files[idx].dir, files[idx].name, defaults[files[idx].dir], opts.BodyTrim)
+				if err != nil {
+					// Unparseable files are skipped; readIssueFile already logged why
+					continue
+				}
+				parsed[idx] = &issue
+			}
+		}()
+	}
+	for idx := range files {
+		work <- idx
+	}
+	close(work)
+	wg.Wait()
+
 	var issues []Issue
-	files, err := ioutil.ReadDir(dir)
+	for _, issue := range parsed {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return filepath.Join(issues[i].Path, issues[i].FileName) < filepath.Join(issues[j].Path, issues[j].FileName)
+	})
+	return issues, nil
+}
+
+// ReadIssueFile reads a single issue file exactly as ReadIssueFilesWithOptions reading root would,
+// including the defaults files from root down to the file's folder. An empty root is the file's
+// own folder.
+func ReadIssueFile(path, root string, bodyTrim BodyTrim) (Issue, error) {
+	dir, name := filepath.Split(path)
+	dir = filepath.Clean(dir)
+	if root == "" {
+		root = dir
+	}
+	defaults, err := ReadDefaultsChain(root, dir)
+	if err != nil {
+		return Issue{}, err
+	}
+	return readIssueFile(dir, name, defaults, bodyTrim)
+}
+
+// readIssueFile parses a single issue markdown file in dir, merging in the folder defaults and
+// trimming the body according to bodyTrim.
+func readIssueFile(dir, name string, defaults map[string]string, bodyTrim BodyTrim) (Issue, error) {
+	frontMatter, nodes, err := mdparser.ReadFrontMatter(filepath.Join(dir, name))
+	if err != nil {
+		logger.Error("Error parsing front matter", "file", name, "error", err)
+		return Issue{}, err
+	}
+	frontMatter["body"] = TrimBody(frontMatter["body"], bodyTrim)
+	normalizeListFields(nodes, frontMatter)
+	applyDefaults(frontMatter, defaults)
+	labelDefinitions, err := structuredLabels(nodes)
+	if err != nil {
+		logger.Error("Error parsing labels", "file", name, "error", err)
+		return Issue{}, fmt.Errorf("%s: %w", filepath.Join(dir, name), err)
+	}
+	targetOwner, targetRepo := ParseRepoTarget(frontMatter["repo"])
+	return Issue{
+		Path:     dir,
+		FileName: name,
+		Title:    frontMatter["title"],
+		Body:     frontMatter["body"],
+		Labels:   SplitLabels(frontMatter["labels"]),
+		Type:     frontMatter["type"],
+		Project:  frontMatter["project"],
+		Parent:   frontMatter["parent"],
+		Id:       frontMatter["id"], // ID will be set after issue creation
+		Owner:    targetOwner,
+		Repo:     targetRepo,
+
+		Assignees:        SplitLabels(frontMatter["assignees"]),
+		Projects:         SplitLabels(frontMatter["projects"]),
+		DependsOn:        SplitLabels(frontMatter["depends_on"]),
+		ExternalID:       strings.TrimSpace(frontMatter["external_id"]),
+		LabelDefinitions: labelDefinitions,
+		ProjectFields:    withStatus(projectFields(nodes, frontMatter), frontMatter["status"]),
+		FrontMatter:      frontMatter,
+	}, nil
+}
+
+// TitleFromH1 sets the title of every issue without a title: key to the first "# Heading" of its
+// body and removes that heading from the body. Issues that have neither are left untouched and
+// reported in the returned error.
+func TitleFromH1(issues []Issue) error {
+	var missing []string
+	for i := range issues {
+		if strings.TrimSpace(issues[i].Title) != "" {
+			continue
+		}
+		title, body, ok := extractH1(issues[i].Body)
+		if !ok {
+			missing = append(missing, filepath.Join(issues[i].Path, issues[i].FileName))
+			continue
+		}
+		issues[i].Title = title
+		issues[i].Body = body
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no title: key and no # heading in %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// extractH1 returns the text of the first level-one ATX heading in body and the body without it.
+// Headings inside fenced code blocks are ignored.
+func extractH1(body string) (title, rest string, ok bool) {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+		title = strings.TrimSpace(strings.TrimRight(strings.TrimPrefix(trimmed, "# "), "#"))
+		if title == "" {
+			continue
+		}
+		remaining := append(append([]string{}, lines[:i]...), lines[i+1:]...)
+		return title, strings.TrimLeft(strings.Join(remaining, "\n"), "\n"), true
+	}
+	return "", body, false
+}
+
+// DuplicateTitles groups issues whose titles are equal ignoring case and surrounding whitespace,
+// since parent linking and dedupe resolve issues by title. Only groups of two or more are returned,
+// in order of first appearance. Issues without a title are ignored.
+func DuplicateTitles(issues []Issue) [][]Issue {
+	groups := make(map[string][]Issue)
+	var order []string
+	for _, issue := range issues {
+		key := strings.ToLower(strings.TrimSpace(issue.Title))
+		if key == "" {
+			continue
+		}
+		if _, seen := groups[key]; !seen {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], issue)
+	}
+
+	var duplicates [][]Issue
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+	return duplicates
+}
+
+// ExternalIDMarker returns the hidden body marker carrying an issue's external id.
+func ExternalIDMarker(externalID string) string {
+	return "<!-- external-id: " + externalID + " -->"
+}
+
+// WithExternalIDMarker appends the external id marker to body unless it is already there.
+func WithExternalIDMarker(body, externalID string) string {
+	marker := ExternalIDMarker(externalID)
+	if externalID == "" || strings.Contains(body, marker) {
+		return body
+	}
+	if body == "" {
+		return marker
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + marker
+}
+
+// TypeFromLabels returns the type mapped to the first of the issue's labels found in mapping
+// (label -> type, matched case-insensitively), or "" if none is.
+func TypeFromLabels(issue Issue, mapping map[string]string) string {
+	for _, label := range issue.Labels {
+		for from, to := range mapping {
+			if strings.EqualFold(strings.TrimSpace(from), strings.TrimSpace(label)) {
+				return to
+			}
+		}
+	}
+	return ""
+}
+
+// PrefixTitle prepends prefix to title unless the title already starts with it, so re-runs don't
+// double the prefix.
+func PrefixTitle(title, prefix string) string {
+	if prefix == "" || title == "" || strings.HasPrefix(title, prefix) {
+		return title
+	}
+	return prefix + title
+}
+
+// PrefixLabel prepends prefix to a label name unless it already starts with it, ignoring case
+// like GitHub's label matching does.
+func PrefixLabel(name, prefix string) string {
+	name = strings.TrimSpace(name)
+	if prefix == "" || name == "" || strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+		return name
+	}
+	return prefix + name
+}
+
+// SplitLabels splits a comma-separated labels value into trimmed, non-empty label names.
+func SplitLabels(value string) []string {
+	labels := []string{}
+	for _, label := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(label)
+		if trimmed != "" {
+			labels = append(labels, trimmed)
+		}
+	}
+	return labels
+}
+
+// ReadTitlesFile reads a plain text file with one issue title per line and returns title-only issues.
+// Blank lines are skipped. The returned issues have no backing markdown file.
+func ReadTitlesFile(path string) ([]Issue, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, file := range files {
-		if file.IsDir() {
+	var issues []Issue
+	for _, line := range strings.Split(string(data), "\n") {
+		title := strings.TrimSpace(line)
+		if title == "" {
 			continue
 		}
+		issues = append(issues, Issue{Title: title, Labels: []string{}})
+	}
+	return issues, nil
+}
 
-		frontMatter, err := mdparser.ParseFrontMatter(filepath.Join(dir, file.Name()))
-		if err != nil {
-			logger.Error("Error parsing front matter", "file", file.Name(), "error", err)
+// FindDependencyCycle returns the issues of the first parent cycle in issues (e.g. A's parent is B
+// and B's parent is A), each followed by its parent, or nil when the parent links form no cycle.
+// Parents are matched by title ignoring case.
+func FindDependencyCycle(issues []Issue) []Issue {
+	byTitle := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		title := strings.ToLower(strings.TrimSpace(issue.Title))
+		if _, ok := byTitle[title]; !ok {
+			byTitle[title] = i
+		}
+	}
+	parentOf := func(i int) int {
+		parent := strings.TrimSpace(issues[i].Parent)
+		if parent == "" {
+			return -1
+		}
+		if j, ok := byTitle[strings.ToLower(parent)]; ok {
+			return j
+		}
+		return -1
+	}
+
+	// Every issue has at most one parent, so following parent links from each unvisited issue
+	// either leaves the batch, reaches an already checked issue, or comes back onto the current path
+	const (
+		unvisited = iota
+		onPath
+		checked
+	)
+	state := make([]int, len(issues))
+	for start := range issues {
+		if state[start] != unvisited {
 			continue
 		}
-		labels := []string{}
-		if lbls, ok := frontMatter["labels"]; ok {
-			// Assuming labels are comma-separated
-			for _, label := range strings.Split(lbls, ",") {
-				trimmed := strings.TrimSpace(label)
-				if trimmed != "" {
-					labels = append(labels, trimmed)
+		var path []int
+		i := start
+		for i >= 0 && state[i] == unvisited {
+			state[i] = onPath
+			path = append(path, i)
+			i = parentOf(i)
+		}
+		if i >= 0 && state[i] == onPath {
+			var cycle []Issue
+			inCycle := false
+			for _, k := range path {
+				inCycle = inCycle || k == i
+				if inCycle {
+					cycle = append(cycle, issues[k])
 				}
 			}
+			return cycle
 		}
-		issue := Issue{
-			Path:     dir,
-			FileName: file.Name(),
-			Title:    frontMatter["title"],
-			Body:     frontMatter["body"],
-			Labels:   labels,
-			Type:     frontMatter["type"],
-			Project:  frontMatter["project"],
-			Parent:   frontMatter["parent"],
-			Id:       frontMatter["id"], // ID will be set after issue creation
+		for _, k := range path {
+			state[k] = checked
 		}
-		issues = append(issues, issue)
 	}
-	return issues, nil
+	return nil
 }
 
 // SortIssuesByDependency sorts issues so that parent issues are created before child issues,
-// with epics processed last to ensure all their child issues are created first.
-func SortIssuesByDependency(issues []Issue) []Issue {
+// with epics processed last to ensure all their child issues are created first. It returns an
+// error naming the titles involved when the parent links form a cycle.
+func SortIssuesByDependency(issues []Issue) ([]Issue, error) {
+	if cycle := FindDependencyCycle(issues); cycle != nil {
+		var titles []string
+		for _, issue := range cycle {
+			titles = append(titles, strconv.Quote(strings.TrimSpace(issue.Title)))
+		}
+		titles = append(titles, titles[0])
+		return nil, fmt.Errorf("dependency cycle in parent links: %s", strings.Join(titles, " -> "))
+	}
+
 	var sortedIssues []Issue
 	var remainingIssues []Issue
 	var epicIssues []Issue
@@ -180,5 +711,73 @@ func SortIssuesByDependency(issues []Issue) []Issue {
 		remainingEpics = stillRemainingEpics
 	}
 
-	return sortedIssues
+	return sortedIssues, nil
+}
+
+// WriteIssueID writes the issue number into the front matter of the markdown file at filePath.
+// An existing id line is updated in place; otherwise a new one is inserted according to position.
+func WriteIssueID(filePath string, number int64, position IDPosition) error {
+	return WriteFrontMatterValue(filePath, "id", strconv.FormatInt(number, 10), position)
+}
+
+// WriteFrontMatterValue sets a single-line front matter key in the markdown file at filePath, the
+// same way WriteIssueID sets the id.
+func WriteFrontMatterValue(filePath, key, value string, position IDPosition) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("read markdown file: %w", err)
+	}
+
+	line := key + ": " + value
+	// Keep the file's line endings so Windows-authored files don't end up with mixed ones
+	newline := "\n"
+	if strings.Contains(string(data), "\r\n") {
+		newline = "\r\n"
+	}
+	bom := mdparser.HasBOM(string(data))
+	lines := strings.Split(mdparser.NormalizeNewlines(mdparser.TrimBOM(string(data))), "\n")
+
+	// Locate the front matter fences
+	start, end := -1, -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			if start == -1 {
+				start = i
+			} else {
+				end = i
+				break
+			}
+		}
+	}
+
+	if start == -1 || end == -1 {
+		// No front matter block, so create one holding just this key
+		lines = append([]string{"---", line, "---"}, lines...)
+	} else {
+		found := false
+		for i := start + 1; i < end; i++ {
+			if strings.HasPrefix(lines[i], key+":") {
+				lines[i] = line
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			insertAt := end
+			if position == IDPositionFirst {
+				insertAt = start + 1
+			}
+			lines = append(lines[:insertAt], append([]string{line}, lines[insertAt:]...)...)
+		}
+	}
+
+	content := strings.Join(lines, newline)
+	if bom {
+		content = "\ufeff" + content
+	}
+	if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write markdown file: %w", err)
+	}
+	return nil
 }
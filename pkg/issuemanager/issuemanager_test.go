@@ -0,0 +1,103 @@
+package issuemanager
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github-issue-manager/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(logger.ErrorLevel, false)
+	os.Exit(m.Run())
+}
+
+func TestUnknownFrontMatterKeys(t *testing.T) {
+	frontMatter := map[string]string{
+		"title":       "T",
+		"Labels":      "bug",
+		"closed_at":   "2024-01-01",
+		"body":        "text",
+		"x-team":      "payments",
+		"_note":       "draft",
+		"- step":      "bogus key from a list item",
+		"parant":      "Epic",
+		"severity":    "High",
+		"repro-steps": "open, click",
+	}
+	want := []string{"parant", "repro-steps", "severity"}
+	if got := UnknownFrontMatterKeys(frontMatter); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownFrontMatterKeys = %v, want %v", got, want)
+	}
+}
+
+func titles(issues []Issue) []string {
+	var out []string
+	for _, issue := range issues {
+		out = append(out, issue.Title)
+	}
+	return out
+}
+
+func TestSortIssuesByDependencyReportsCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		issues  []Issue
+		wantErr string
+	}{
+		{
+			name: "two-node cycle",
+			issues: []Issue{
+				{Title: "Root"},
+				{Title: "A", Parent: "B"},
+				{Title: "B", Parent: "a"},
+			},
+			wantErr: `dependency cycle in parent links: "A" -> "B" -> "A"`,
+		},
+		{
+			name: "three-node cycle behind a chain",
+			issues: []Issue{
+				{Title: "Leaf", Parent: "A"},
+				{Title: "A", Parent: "B"},
+				{Title: "B", Parent: "C"},
+				{Title: "C", Parent: "A"},
+			},
+			wantErr: `dependency cycle in parent links: "A" -> "B" -> "C" -> "A"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := SortIssuesByDependency(tt.issues)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("SortIssuesByDependency error = %v, want %q", err, tt.wantErr)
+			}
+			if sorted != nil {
+				t.Errorf("SortIssuesByDependency returned %v alongside the error", titles(sorted))
+			}
+		})
+	}
+}
+
+func TestSortIssuesByDependencyOrdersParentsFirst(t *testing.T) {
+	issues := []Issue{
+		{Title: "Grandchild", Parent: "Child"},
+		{Title: "Epic", Type: "Epic"},
+		{Title: "Child", Parent: "Root"},
+		{Title: "Root"},
+		{Title: "Orphan", Parent: "Missing"},
+	}
+	if cycle := FindDependencyCycle(issues); cycle != nil {
+		t.Fatalf("FindDependencyCycle = %v, want nil", titles(cycle))
+	}
+
+	sorted, err := SortIssuesByDependency(issues)
+	if err != nil {
+		t.Fatalf("SortIssuesByDependency: %v", err)
+	}
+	want := []string{"Root", "Child", "Grandchild", "Orphan", "Epic"}
+	if got := titles(sorted); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortIssuesByDependency = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package issuemanager
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LabelDefinition describes a label that should exist in the target repository.
+type LabelDefinition struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+}
+
+// UnmarshalYAML accepts either a plain label name or a mapping with name, color and description.
+func (l *LabelDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		l.Name = node.Value
+		return nil
+	}
+
+	type plain LabelDefinition
+	var def plain
+	if err := node.Decode(&def); err != nil {
+		return err
+	}
+	*l = LabelDefinition(def)
+	return nil
+}
+
+// ReadLabelsFile reads a YAML list of label definitions. Entries may be plain names or
+// mappings with name, color and description keys.
+func ReadLabelsFile(path string) ([]LabelDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []LabelDefinition
+	if err := yaml.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("parse labels file %s: %w", path, err)
+	}
+
+	for i, label := range labels {
+		labels[i].Name = strings.TrimSpace(label.Name)
+		labels[i].Color = strings.TrimPrefix(strings.TrimSpace(label.Color), "#")
+		if labels[i].Name == "" {
+			return nil, fmt.Errorf("parse labels file %s: entry %d has no name", path, i+1)
+		}
+	}
+	return labels, nil
+}
+
+// structuredLabels returns the label definitions of a file whose labels front matter value is a
+// YAML list (of names or name/color/description mappings), given the file's front matter nodes. It
+// returns nil when labels is missing or uses the plain comma-separated form.
+func structuredLabels(nodes map[string]*yaml.Node) ([]LabelDefinition, error) {
+	node, ok := nodes["labels"]
+	if !ok || node.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	var labels []LabelDefinition
+	if err := node.Decode(&labels); err != nil {
+		return nil, fmt.Errorf("parse labels: %w", err)
+	}
+	for i, label := range labels {
+		labels[i].Name = strings.TrimSpace(label.Name)
+		labels[i].Color = strings.TrimPrefix(strings.TrimSpace(label.Color), "#")
+	}
+	return labels, nil
+}
@@ -0,0 +1,136 @@
+package issuemanager
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// markdownLink matches inline markdown links and images: [text](target "title") and ![alt](target).
+var markdownLink = regexp.MustCompile(`(!?\[[^\]]*\]\()(<[^>]*>|[^)\s]+)((?:\s+"[^"]*")?\))`)
+
+// RelativeLinks returns the targets of inline links and images in body that point at local paths
+// (no scheme, no host and not a same-page #anchor), in order of appearance. Links inside fenced
+// code blocks are ignored.
+func RelativeLinks(body string) []string {
+	var links []string
+	outsideCodeFences(body, func(text string) string {
+		for _, match := range markdownLink.FindAllStringSubmatch(text, -1) {
+			if target := strings.Trim(match[2], "<>"); isRelativeLink(target) {
+				links = append(links, target)
+			}
+		}
+		return text
+	})
+	return links
+}
+
+// RewriteRelativeLinks resolves relative link and image targets in body against base, e.g. the raw
+// content URL of the issue folder at a given branch. Targets starting with "/" are resolved against
+// the repository root instead: the owner/repo/ref prefix of raw.githubusercontent.com URLs or the
+// owner/repo/blob/ref prefix of github.com URLs, and the host root for any other base. Absolute
+// URLs, #anchors and links inside fenced code blocks are left untouched.
+func RewriteRelativeLinks(body, base string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return "", fmt.Errorf("invalid link base %q: must be an absolute URL", base)
+	}
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		// The base names a folder; without the slash the last segment would be replaced
+		baseURL.Path += "/"
+	}
+	rootURL := repositoryRoot(baseURL)
+
+	return outsideCodeFences(body, func(text string) string {
+		return markdownLink.ReplaceAllStringFunc(text, func(link string) string {
+			match := markdownLink.FindStringSubmatch(link)
+			target := strings.Trim(match[2], "<>")
+			if !isRelativeLink(target) {
+				return link
+			}
+			against := baseURL
+			if strings.HasPrefix(target, "/") {
+				against = rootURL
+			}
+			ref, err := url.Parse(strings.TrimPrefix(target, "/"))
+			if err != nil {
+				return link
+			}
+			return match[1] + against.ResolveReference(ref).String() + match[3]
+		})
+	}), nil
+}
+
+// repositoryRoot returns the URL root-relative ("/docs/a.png") links resolve against for base.
+func repositoryRoot(base *url.URL) *url.URL {
+	segments := strings.Split(strings.Trim(base.Path, "/"), "/")
+	n := 0
+	switch {
+	case base.Host == "raw.githubusercontent.com" && len(segments) >= 3:
+		n = 3 // owner/repo/ref
+	case base.Host == "github.com" && len(segments) >= 4 && (segments[2] == "blob" || segments[2] == "raw" || segments[2] == "tree"):
+		n = 4 // owner/repo/blob/ref
+	}
+
+	root := *base
+	root.Path = "/"
+	if n > 0 {
+		root.Path += strings.Join(segments[:n], "/") + "/"
+	}
+	return &root
+}
+
+// outsideCodeFences applies fn to the parts of body outside ``` and ~~~ fenced code blocks and
+// returns body with those parts replaced. An unclosed fence runs to the end of body.
+func outsideCodeFences(body string, fn func(text string) string) string {
+	var out, text strings.Builder
+	fence := "" // the opening fence while inside a fenced block
+	for _, line := range strings.SplitAfter(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			if marker := fenceMarker(line); marker != "" {
+				out.WriteString(fn(text.String()))
+				text.Reset()
+				fence = marker
+				out.WriteString(line)
+				continue
+			}
+			text.WriteString(line)
+			continue
+		}
+
+		out.WriteString(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			fence = ""
+		}
+	}
+	out.WriteString(fn(text.String()))
+	return out.String()
+}
+
+// fenceMarker returns the backtick or tilde run opening a fenced code block on line, or "".
+func fenceMarker(line string) string {
+	indented := strings.TrimLeft(line, " ")
+	if len(line)-len(indented) > 3 {
+		return ""
+	}
+	for _, char := range []string{"`", "~"} {
+		marker := indented[:len(indented)-len(strings.TrimLeft(indented, char))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+	return ""
+}
+
+func isRelativeLink(target string) bool {
+	if target == "" || strings.HasPrefix(target, "#") {
+		return false
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "" && parsed.Host == ""
+}
@@ -0,0 +1,75 @@
+package issuemanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteRelativeLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		body string
+		want string
+	}{
+		{
+			name: "relative to the issue folder",
+			base: "https://raw.githubusercontent.com/octo/hello/main/issues",
+			body: "![diagram](./diagram.png) and [spec](../docs/spec.md \"Spec\")",
+			want: "![diagram](https://raw.githubusercontent.com/octo/hello/main/issues/diagram.png) and [spec](https://raw.githubusercontent.com/octo/hello/main/docs/spec.md \"Spec\")",
+		},
+		{
+			name: "root-relative on raw.githubusercontent.com",
+			base: "https://raw.githubusercontent.com/octo/hello/main/issues/",
+			body: "![logo](/assets/logo.png)",
+			want: "![logo](https://raw.githubusercontent.com/octo/hello/main/assets/logo.png)",
+		},
+		{
+			name: "root-relative on github.com",
+			base: "https://github.com/octo/hello/blob/v2/issues/",
+			body: "[guide](/CONTRIBUTING.md)",
+			want: "[guide](https://github.com/octo/hello/blob/v2/CONTRIBUTING.md)",
+		},
+		{
+			name: "root-relative on another host",
+			base: "https://example.com/files/issues/",
+			body: "[x](/a.png)",
+			want: "[x](https://example.com/a.png)",
+		},
+		{
+			name: "absolute URLs and anchors untouched",
+			base: "https://example.com/issues/",
+			body: "[a](https://github.com) [b](#steps) [c](//cdn.example.com/x.png)",
+			want: "[a](https://github.com) [b](#steps) [c](//cdn.example.com/x.png)",
+		},
+		{
+			name: "fenced code blocks untouched",
+			base: "https://example.com/issues/",
+			body: "[a](a.md)\n```md\n[b](b.md)\n```\n~~~~\n[c](c.md)\n```\n~~~~\n[d](d.md)\n",
+			want: "[a](https://example.com/issues/a.md)\n```md\n[b](b.md)\n```\n~~~~\n[c](c.md)\n```\n~~~~\n[d](https://example.com/issues/d.md)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RewriteRelativeLinks(tt.body, tt.base)
+			if err != nil {
+				t.Fatalf("RewriteRelativeLinks: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RewriteRelativeLinks =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := RewriteRelativeLinks("", "issues/"); err == nil {
+		t.Error("relative base accepted, want an error")
+	}
+}
+
+func TestRelativeLinksSkipsCodeFences(t *testing.T) {
+	body := "![](./a.png)\n```\n![](./b.png)\n```\n[c](c.md) [web](https://example.com)"
+	if got, want := RelativeLinks(body), []string{"./a.png", "c.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RelativeLinks = %v, want %v", got, want)
+	}
+}
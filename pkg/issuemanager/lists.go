@@ -0,0 +1,43 @@
+package issuemanager
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	mdparser "github-issue-manager/pkg/mdparser"
+)
+
+// ListFrontMatterKeys are the multi-value front matter keys, which may be written either as a YAML
+// list or as a comma-separated string.
+var ListFrontMatterKeys = []string{"labels", "assignees", "projects", "depends_on"}
+
+// ListValues normalizes a multi-value field into trimmed, non-empty values. The node may be a YAML
+// list, whose entries are plain values or mappings with a name key (like structured labels), or a
+// comma-separated string.
+func ListValues(node *yaml.Node) []string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return SplitLabels(node.Value)
+	case yaml.SequenceNode:
+		return mdparser.SequenceValues(node)
+	}
+	return []string{}
+}
+
+// normalizeListFields rewrites the multi-value fields of a file's flat front matter into the
+// comma-separated form, whichever form the file uses. Values are taken from the file's YAML nodes
+// (see mdparser.ReadFrontMatter) when it has them; front matter that isn't valid YAML keeps its
+// comma-separated values, with a flow list like "[bug, ui]" unwrapped.
+func normalizeListFields(nodes map[string]*yaml.Node, frontMatter map[string]string) {
+	for _, key := range ListFrontMatterKeys {
+		node, ok := nodes[key]
+		if !ok {
+			if value, ok := frontMatter[key]; ok {
+				frontMatter[key] = strings.Join(SplitLabels(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")), ", ")
+			}
+			continue
+		}
+		frontMatter[key] = strings.Join(ListValues(node), ", ")
+	}
+}
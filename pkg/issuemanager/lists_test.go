@@ -0,0 +1,86 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListFieldsAcceptBothShapes(t *testing.T) {
+	dir := t.TempDir()
+	for _, key := range ListFrontMatterKeys {
+		for name, value := range map[string]string{
+			"list":          "\n  - one\n  - name: two\n",
+			"flow list":     " [one, two]",
+			"comma string":  " one, two,",
+			"flow no yaml":  " [one, two]\ntitle: Fix: it",
+			"comma no yaml": " one , two\ntitle: Fix: it",
+		} {
+			t.Run(key+"/"+name, func(t *testing.T) {
+				path := filepath.Join(dir, "issue.md")
+				if err := os.WriteFile(path, []byte("---\n"+key+":"+value+"\n---\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				issue, err := ReadIssueFile(path, "", BodyTrimEdges)
+				if err != nil {
+					t.Fatalf("ReadIssueFile: %v", err)
+				}
+				if got := issue.FrontMatter[key]; got != "one, two" {
+					t.Errorf("%s = %q, want %q", key, got, "one, two")
+				}
+			})
+		}
+	}
+}
+
+func TestIssueListFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue.md")
+	content := "---\nlabels: bug, ui\nassignees:\n  - octocat\n  - hubot\nprojects: [Roadmap, Q3]\ndepends_on:\n  - Login page\n---\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue, err := ReadIssueFile(path, "", BodyTrimEdges)
+	if err != nil {
+		t.Fatalf("ReadIssueFile: %v", err)
+	}
+	if want := []string{"bug", "ui"}; !reflect.DeepEqual(issue.Labels, want) {
+		t.Errorf("Labels = %v, want %v", issue.Labels, want)
+	}
+	if want := []string{"octocat", "hubot"}; !reflect.DeepEqual(issue.Assignees, want) {
+		t.Errorf("Assignees = %v, want %v", issue.Assignees, want)
+	}
+	if want := []string{"Roadmap", "Q3"}; !reflect.DeepEqual(issue.Projects, want) {
+		t.Errorf("Projects = %v, want %v", issue.Projects, want)
+	}
+	if want := []string{"Login page"}; !reflect.DeepEqual(issue.DependsOn, want) {
+		t.Errorf("DependsOn = %v, want %v", issue.DependsOn, want)
+	}
+	if unknown := UnknownFrontMatterKeys(issue.FrontMatter); len(unknown) != 0 {
+		t.Errorf("UnknownFrontMatterKeys = %v, want none", unknown)
+	}
+}
+
+func TestBatchListFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.yaml")
+	content := "- title: T\n  labels: [bug]\n  assignees: octocat, hubot\n  projects: Roadmap\n  depends_on: [Login page, Signup]\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := ReadBatchFile(path)
+	if err != nil {
+		t.Fatalf("ReadBatchFile: %v", err)
+	}
+	issue := issues[0]
+	if want := []string{"octocat", "hubot"}; !reflect.DeepEqual(issue.Assignees, want) {
+		t.Errorf("Assignees = %v, want %v", issue.Assignees, want)
+	}
+	if want := []string{"Roadmap"}; !reflect.DeepEqual(issue.Projects, want) {
+		t.Errorf("Projects = %v, want %v", issue.Projects, want)
+	}
+	if want := []string{"Login page", "Signup"}; !reflect.DeepEqual(issue.DependsOn, want) {
+		t.Errorf("DependsOn = %v, want %v", issue.DependsOn, want)
+	}
+}
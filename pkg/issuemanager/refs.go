@@ -0,0 +1,26 @@
+package issuemanager
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// closingKeyword matches GitHub's closing keywords followed by a same-repository issue reference,
+// e.g. "Closes #12", "fixed #3" or "Resolves: #7".
+var closingKeyword = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// ClosingReferences returns the issue numbers referenced with a closing keyword in body, without
+// duplicates and in order of first appearance.
+func ClosingReferences(body string) []int64 {
+	var numbers []int64
+	seen := make(map[int64]bool)
+	for _, match := range closingKeyword.FindAllStringSubmatch(body, -1) {
+		number, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || number <= 0 || seen[number] {
+			continue
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
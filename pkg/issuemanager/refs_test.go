@@ -0,0 +1,40 @@
+package issuemanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClosingReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []int64
+	}{
+		{
+			name: "keyword variants",
+			body: "close #1, closes #2, closed #3\nfix #4, fixes #5, fixed #6\nresolve #7, resolves #8, Resolved #9",
+			want: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{name: "colon after keyword", body: "Closes: #7", want: []int64{7}},
+		{name: "case insensitive", body: "FIXES #12", want: []int64{12}},
+		{
+			name: "duplicates keep first appearance",
+			body: "Fixes #3. Closes #1. Resolves #3 and closes #1 again.",
+			want: []int64{3, 1},
+		},
+		{name: "number followed by letters", body: "Closes #12abc", want: nil},
+		{name: "cross-repository reference", body: "Closes other/repo#5 and fixes other#6", want: nil},
+		{name: "keyword inside another word", body: "prefixes #4, unresolved #5", want: nil},
+		{name: "plain mention", body: "See #8 for details", want: nil},
+		{name: "zero is not an issue", body: "Closes #0", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClosingReferences(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClosingReferences(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
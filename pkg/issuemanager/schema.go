@@ -0,0 +1,214 @@
+package issuemanager
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	mdparser "github-issue-manager/pkg/mdparser"
+)
+
+// Schema describes the front matter conventions of a project: which keys are allowed, their kind
+// and their allowed values.
+//
+//	allow_unknown: false
+//	fields:
+//	  title: {required: true}
+//	  type: {enum: [Bug, Task, Feature]}
+//	  labels: {type: list}
+//	  severity: {enum: [Low, Medium, High, Critical]}
+type Schema struct {
+	// AllowUnknown permits keys that aren't listed in Fields. Keys prefixed with x- or _ are always allowed.
+	AllowUnknown bool                   `yaml:"allow_unknown"`
+	Fields       map[string]SchemaField `yaml:"fields"`
+}
+
+// SchemaField constrains a single front matter key.
+type SchemaField struct {
+	// Type is "string" (the default) or "list" (a YAML list or a comma-separated string).
+	Type     string   `yaml:"type"`
+	Enum     []string `yaml:"enum"`
+	Required bool     `yaml:"required"`
+}
+
+// SchemaViolation is a front matter value that doesn't match the schema. Line is the 1-based line in
+// the file.
+type SchemaViolation struct {
+	Line    int
+	Message string
+}
+
+// LoadSchema reads a schema file.
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema Schema
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("parse schema file %s: %w", path, err)
+	}
+	for key, field := range schema.Fields {
+		switch field.Type {
+		case "", "string", "list":
+		default:
+			return nil, fmt.Errorf("parse schema file %s: field %q has unknown type %q (use string or list)", path, key, field.Type)
+		}
+	}
+	return &schema, nil
+}
+
+// Check validates the front matter of the markdown file at path, merged with the folder defaults
+// that apply to it (see ReadDefaultsChain), and returns the violations in line order. Violations in
+// values that come from the defaults are reported on the opening fence line.
+func (s *Schema) Check(path string, defaults map[string]string) ([]SchemaViolation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := mdparser.RawFrontMatter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Node lines are relative to the block, which starts with the rest of the opening fence line
+	content := mdparser.NormalizeNewlines(mdparser.TrimBOM(string(data)))
+	fenceLine := strings.Count(content[:strings.Index(content, raw)], "\n") + 1
+	block := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(raw), "---"), "---")
+
+	var doc yaml.Node
+	if raw != "" {
+		if err := yaml.Unmarshal([]byte(block), &doc); err != nil {
+			return []SchemaViolation{{Line: fenceLine, Message: fmt.Sprintf("front matter is not valid YAML: %v", err)}}, nil
+		}
+	}
+
+	var violations []SchemaViolation
+	seen := make(map[string]bool)
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		mapping := doc.Content[0].Content
+		for i := 0; i+1 < len(mapping); i += 2 {
+			keyNode, valueNode := mapping[i], mapping[i+1]
+			key := strings.ToLower(strings.TrimSpace(keyNode.Value))
+			seen[key] = true
+			line := fenceLine + keyNode.Line - 1
+
+			field, ok := s.field(key)
+			if !ok {
+				if !s.AllowUnknown && !strings.HasPrefix(key, "x-") && !strings.HasPrefix(key, "_") {
+					violations = append(violations, SchemaViolation{Line: line, Message: fmt.Sprintf("key %q is not allowed by the schema", keyNode.Value)})
+				}
+				continue
+			}
+			for _, message := range field.check(valueNode) {
+				violations = append(violations, SchemaViolation{Line: line, Message: fmt.Sprintf("%s: %s", keyNode.Value, message)})
+			}
+		}
+	}
+
+	// Defaults fill keys the file leaves out; default labels are added to the file's own
+	var defaultKeys []string
+	for key := range defaults {
+		if !seen[key] || key == "labels" {
+			defaultKeys = append(defaultKeys, key)
+		}
+	}
+	sort.Strings(defaultKeys)
+	for _, key := range defaultKeys {
+		seen[key] = true
+		field, ok := s.field(key)
+		if !ok {
+			if !s.AllowUnknown && !strings.HasPrefix(key, "x-") && !strings.HasPrefix(key, "_") {
+				violations = append(violations, SchemaViolation{Line: fenceLine, Message: fmt.Sprintf("key %q (from %s) is not allowed by the schema", key, DefaultsFileName)})
+			}
+			continue
+		}
+		for _, message := range field.check(&yaml.Node{Kind: yaml.ScalarNode, Value: defaults[key]}) {
+			violations = append(violations, SchemaViolation{Line: fenceLine, Message: fmt.Sprintf("%s (from %s): %s", key, DefaultsFileName, message)})
+		}
+	}
+
+	var required []string
+	for key, field := range s.Fields {
+		if field.Required && !seen[strings.ToLower(key)] {
+			required = append(required, key)
+		}
+	}
+	sort.Strings(required)
+	for _, key := range required {
+		violations = append(violations, SchemaViolation{Line: fenceLine, Message: fmt.Sprintf("required key %q is missing", key)})
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Line < violations[j].Line })
+	return violations, nil
+}
+
+func (s *Schema) field(key string) (SchemaField, bool) {
+	for name, field := range s.Fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return SchemaField{}, false
+}
+
+// check returns the problems with a single value.
+func (f SchemaField) check(node *yaml.Node) []string {
+	var values []string
+	switch {
+	case f.Type == "list" && node.Kind == yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.MappingNode {
+				// Structured entries (e.g. labels with a color) are checked by name
+				var entry struct {
+					Name string `yaml:"name"`
+				}
+				if err := item.Decode(&entry); err == nil {
+					values = append(values, entry.Name)
+				}
+				continue
+			}
+			values = append(values, item.Value)
+		}
+	case f.Type == "list" && node.Kind == yaml.ScalarNode:
+		values = SplitLabels(node.Value)
+	case node.Kind == yaml.ScalarNode:
+		values = []string{node.Value}
+	default:
+		return []string{fmt.Sprintf("expected a %s value", f.kind())}
+	}
+
+	if len(f.Enum) == 0 {
+		return nil
+	}
+	var problems []string
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if !containsFold(f.Enum, value) {
+			problems = append(problems, fmt.Sprintf("%q is not one of %s", value, strings.Join(f.Enum, ", ")))
+		}
+	}
+	return problems
+}
+
+func (f SchemaField) kind() string {
+	if f.Type == "" {
+		return "string"
+	}
+	return f.Type
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
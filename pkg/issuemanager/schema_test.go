@@ -0,0 +1,64 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaCheckMergesDefaults(t *testing.T) {
+	schema := &Schema{Fields: map[string]SchemaField{
+		"title":   {Required: true},
+		"type":    {Required: true, Enum: []string{"Bug", "Task"}},
+		"labels":  {Type: "list", Enum: []string{"bug", "backend"}},
+		"project": {},
+	}}
+	path := filepath.Join(t.TempDir(), "issue.md")
+	if err := os.WriteFile(path, []byte("---\ntitle: T\nlabels: [bug]\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		want     []string
+	}{
+		{
+			name: "no defaults",
+			want: []string{`required key "type" is missing`},
+		},
+		{
+			name:     "default fills a required key",
+			defaults: map[string]string{"type": "Task", "project": "Roadmap"},
+		},
+		{
+			name:     "default values are checked",
+			defaults: map[string]string{"type": "Epic", "labels": "backend, frontend", "assignees": "octocat"},
+			want: []string{
+				`key "assignees" (from defaults.yaml) is not allowed by the schema`,
+				`labels (from defaults.yaml): "frontend" is not one of bug, backend`,
+				`type (from defaults.yaml): "Epic" is not one of Bug, Task`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := schema.Check(path, tt.defaults)
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			var got []string
+			for _, violation := range violations {
+				if violation.Line != 1 {
+					t.Errorf("%q on line %d, want line 1", violation.Message, violation.Line)
+				}
+				got = append(got, violation.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
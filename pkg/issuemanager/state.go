@@ -0,0 +1,110 @@
+package issuemanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// DefaultStateFile is the state manifest written by create runs when no other path is given.
+const DefaultStateFile = ".gim-state.json"
+
+// StateManifest records which issues a create run has already processed, keyed by file (or title
+// for issues without a file) with the content hash they were processed at. It is saved after every
+// entry so an interrupted run can be resumed.
+type StateManifest struct {
+	path string
+	mu   sync.Mutex
+
+	Processed map[string]string `json:"processed"` // issue key -> content hash
+}
+
+// LoadState reads the state manifest at path. A missing manifest is returned empty.
+func LoadState(path string) (*StateManifest, error) {
+	state := &StateManifest{path: path, Processed: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", path, err)
+	}
+	if state.Processed == nil {
+		state.Processed = make(map[string]string)
+	}
+	return state, nil
+}
+
+// Done reports whether issue was processed at its current content.
+func (s *StateManifest) Done(issue Issue) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hash, ok := s.Processed[StateKey(issue)]
+	return ok && hash == ContentHash(issue)
+}
+
+// Record marks issue as processed at hash and saves the manifest.
+func (s *StateManifest) Record(issue Issue, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Processed[StateKey(issue)] = hash
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so an interruption never leaves a truncated manifest
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Remove deletes the manifest once a run has completed.
+func (s *StateManifest) Remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// StateKey identifies an issue in the state manifest: its file, or its title when it has none.
+func StateKey(issue Issue) string {
+	if issue.FileName != "" {
+		return filepath.Join(issue.Path, issue.FileName)
+	}
+	return "title:" + issue.Title
+}
+
+// ContentHash hashes the content an issue was read with, ignoring its id so that writing the new
+// issue number back doesn't change the hash.
+func ContentHash(issue Issue) string {
+	fields := issue.FrontMatter
+	if fields == nil {
+		fields = map[string]string{"title": issue.Title, "body": issue.Body}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if key != "id" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s\x00%s\x00", key, fields[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
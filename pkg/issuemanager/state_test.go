@@ -0,0 +1,106 @@
+package issuemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readOne(t *testing.T, dir string) Issue {
+	t.Helper()
+	issues, err := ReadIssueFiles(dir)
+	if err != nil {
+		t.Fatalf("ReadIssueFiles: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("read %d issues, want 1", len(issues))
+	}
+	return issues[0]
+}
+
+func TestContentHashIgnoresIDWriteBack(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.md": "---\ntitle: A\nlabels: bug\n---\nBody\n"})
+
+	before := ContentHash(readOne(t, dir))
+	if err := WriteIssueID(filepath.Join(dir, "a.md"), 42, IDPositionLast); err != nil {
+		t.Fatalf("WriteIssueID: %v", err)
+	}
+	issue := readOne(t, dir)
+	if issue.Id != "42" {
+		t.Fatalf("id = %q, want 42", issue.Id)
+	}
+	if after := ContentHash(issue); after != before {
+		t.Errorf("ContentHash changed after writing the id back: %s -> %s", before, after)
+	}
+}
+
+func TestStateManifestResume(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"done/a.md":   "---\ntitle: A\n---\nBody\n",
+		"edited/b.md": "---\ntitle: B\n---\nBody\n",
+		"failed/c.md": "---\ntitle: C\n---\nBody\n",
+	})
+	statePath := filepath.Join(dir, DefaultStateFile)
+
+	state, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("LoadState without a manifest: %v", err)
+	}
+	a := readOne(t, filepath.Join(dir, "done"))
+	b := readOne(t, filepath.Join(dir, "edited"))
+	c := readOne(t, filepath.Join(dir, "failed"))
+	title := Issue{Title: "From titles file"}
+	for _, issue := range []Issue{a, b, c, title} {
+		if state.Done(issue) {
+			t.Errorf("%s: Done before anything was recorded", issue.Title)
+		}
+	}
+	// C failed, so the run never records it
+	for _, issue := range []Issue{a, b, title} {
+		if err := state.Record(issue, ContentHash(issue)); err != nil {
+			t.Fatalf("Record %s: %v", issue.Title, err)
+		}
+	}
+
+	// The run is interrupted; B is edited before it is resumed
+	writeTree(t, dir, map[string]string{"edited/b.md": "---\ntitle: B\n---\nBody with a fix\n"})
+	b = readOne(t, filepath.Join(dir, "edited"))
+
+	resumed, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	tests := []struct {
+		issue Issue
+		want  bool
+	}{
+		{a, true},
+		{b, false},
+		{c, false},
+		{title, true},
+	}
+	for _, tt := range tests {
+		if got := resumed.Done(tt.issue); got != tt.want {
+			t.Errorf("%s: Done = %v, want %v", tt.issue.Title, got, tt.want)
+		}
+	}
+
+	if err := resumed.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("state file still exists after Remove: %v", err)
+	}
+}
+
+func TestLoadStateRejectsCorruptManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultStateFile)
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(path); err == nil {
+		t.Error("LoadState succeeded on a corrupt manifest")
+	}
+}
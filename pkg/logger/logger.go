@@ -1,12 +1,23 @@
 package logger
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"sync"
 )
 
 var Logger *slog.Logger
 
+// level is shared by every handler created by Init so it can be raised after start-up
+var currentLevel slog.LevelVar
+
+var (
+	warningsMu sync.Mutex
+	warnings   []string
+)
+
 // LogLevel represents the available log levels
 type LogLevel string
 
@@ -34,8 +45,9 @@ func Init(level LogLevel, jsonFormat bool) {
 		logLevel = slog.LevelInfo
 	}
 
+	currentLevel.Set(logLevel)
 	opts := &slog.HandlerOptions{
-		Level: logLevel,
+		Level: &currentLevel,
 	}
 
 	var handler slog.Handler
@@ -49,6 +61,14 @@ func Init(level LogLevel, jsonFormat bool) {
 	slog.SetDefault(Logger)
 }
 
+// SuppressInfo raises the log level to warn unless it is already higher, so only warnings and
+// errors are logged
+func SuppressInfo() {
+	if currentLevel.Level() < slog.LevelWarn {
+		currentLevel.Set(slog.LevelWarn)
+	}
+}
+
 // Debug logs a debug message
 func Debug(msg string, args ...any) {
 	Logger.Debug(msg, args...)
@@ -59,9 +79,30 @@ func Info(msg string, args ...any) {
 	Logger.Info(msg, args...)
 }
 
-// Warn logs a warning message
+// Warn logs a warning message and records it for the end-of-run summary
 func Warn(msg string, args ...any) {
 	Logger.Warn(msg, args...)
+
+	warningsMu.Lock()
+	defer warningsMu.Unlock()
+	warnings = append(warnings, formatRecord(msg, args...))
+}
+
+// Warnings returns every warning recorded since the program started
+func Warnings() []string {
+	warningsMu.Lock()
+	defer warningsMu.Unlock()
+	return append([]string(nil), warnings...)
+}
+
+// formatRecord renders a message and its key/value pairs as a single line
+func formatRecord(msg string, args ...any) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	return b.String()
 }
 
 // Error logs an error message
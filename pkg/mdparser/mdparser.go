@@ -1,9 +1,12 @@
 package mdparser
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // ListMarkdownFiles returns a slice of markdown file paths in the specified folder.
@@ -21,40 +24,259 @@ func ListMarkdownFiles(folder string) ([]string, error) {
 	return files, nil
 }
 
-// ParseFrontMatter extracts key-value pairs from the front matter block in a markdown file.
+// ListMarkdownFilesRecursive returns the markdown file paths in folder and all of its
+// subdirectories, in lexical order. Symlinks to markdown files are listed, but symlinked
+// directories are not followed.
+func ListMarkdownFilesRecursive(folder string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			return nil
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			// Skip links to directories and dangling links
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// utf8BOM is the byte order mark some editors put at the start of UTF-8 files.
+const utf8BOM = "\ufeff"
+
+// TrimBOM removes a leading UTF-8 byte order mark, which would otherwise hide the opening front
+// matter fence.
+func TrimBOM(s string) string {
+	return strings.TrimPrefix(s, utf8BOM)
+}
+
+// HasBOM reports whether s starts with a UTF-8 byte order mark.
+func HasBOM(s string) bool {
+	return strings.HasPrefix(s, utf8BOM)
+}
+
+// NormalizeNewlines converts Windows (\r\n) and old Mac (\r) line endings to \n.
+func NormalizeNewlines(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
+
+// ParseFrontMatter extracts key-value pairs from the front matter block in a markdown file. The
+// block is read as YAML: scalar values are returned as written (without quotes), and lists of values
+// (or of mappings with a name key, like structured labels) are joined with ", ". Nested mappings
+// are returned empty; callers that need them use ReadFrontMatter. Blocks that aren't valid YAML,
+// such as "title: Fix: login bug", fall back to a line-by-line "key: value" reading. The text after
+// the block is returned verbatim under the "body" key, indentation included, apart from line
+// endings normalized to "\n" and the file's final newline.
 func ParseFrontMatter(path string) (map[string]string, error) {
+	values, _, err := ReadFrontMatter(path)
+	return values, err
+}
+
+// ReadFrontMatter is ParseFrontMatter that also returns the YAML value of every front matter key,
+// for callers that need lists and mappings as written. The nodes are nil when the file has no
+// front matter or its block isn't valid YAML.
+func ReadFrontMatter(path string) (map[string]string, map[string]*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, body := splitFrontMatter(strings.Split(NormalizeNewlines(TrimBOM(string(data))), "\n"))
+	nodes, ok := parseYAMLBlock(block)
+	var result map[string]string
+	if ok {
+		result = make(map[string]string, len(nodes)+1)
+		for key, node := range nodes {
+			result[key] = flattenNode(node)
+		}
+	} else {
+		result = parseFlatBlock(block)
+	}
+
+	// Body lines are kept as written; callers choose how much whitespace to trim.
+	// The file's final newline ends the last line rather than adding an empty one
+	result["body"] = strings.TrimSuffix(strings.Join(body, "\n"), "\n")
+	return result, nodes, nil
+}
+
+// splitFrontMatter splits the lines of a file into the front matter block between the first two
+// "---" fences and the body after it. Only blank lines may precede the opening fence; a file
+// without one is all body.
+func splitFrontMatter(lines []string) (block, body []string) {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.TrimSpace(line) != "---" {
+			return nil, lines
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "---" {
+				return lines[i+1 : j], lines[j+1:]
+			}
+		}
+		// An unclosed block runs to the end of the file
+		return lines[i+1:], nil
+	}
+	return nil, lines
+}
+
+// parseYAMLBlock parses a YAML front matter block into its values keyed by name, with aliases
+// resolved. It reports false if the block isn't a valid YAML mapping; an empty block has no values.
+func parseYAMLBlock(block []string) (map[string]*yaml.Node, bool) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &doc); err != nil {
+		return nil, false
+	}
+	if len(doc.Content) == 0 {
+		// Empty block
+		return nil, true
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, false
+	}
+
+	nodes := make(map[string]*yaml.Node, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		value := root.Content[i+1]
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+		nodes[strings.TrimSpace(root.Content[i].Value)] = value
+	}
+	return nodes, true
+}
+
+// flattenNode renders a front matter value as a single string.
+func flattenNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return strings.TrimSpace(node.Value)
+	case yaml.SequenceNode:
+		return strings.Join(SequenceValues(node), ", ")
+	default:
+		return ""
+	}
+}
+
+// SequenceValues returns the trimmed, non-empty entries of a YAML list. Entries are plain values or
+// mappings with a name key (like structured labels), which yield their name.
+func SequenceValues(node *yaml.Node) []string {
+	values := []string{}
+	for _, item := range node.Content {
+		if item.Kind == yaml.AliasNode {
+			item = item.Alias
+		}
+		var value string
+		switch item.Kind {
+		case yaml.ScalarNode:
+			value = item.Value
+		case yaml.MappingNode:
+			var entry struct {
+				Name string `yaml:"name"`
+			}
+			if err := item.Decode(&entry); err == nil {
+				value = entry.Name
+			}
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseFlatBlock reads a front matter block line by line as "key: value" pairs, splitting on the
+// first colon and removing surrounding double quotes. A key without a value followed by "- item"
+// lines takes the items joined with ", "; other nested (indented) lines are skipped.
+func parseFlatBlock(block []string) map[string]string {
 	result := make(map[string]string)
+	listKey := ""
+	for _, raw := range block {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if isNestedLine(raw) {
+			if item, ok := listItem(line); ok && listKey != "" && item != "" {
+				if result[listKey] != "" {
+					result[listKey] += ", "
+				}
+				result[listKey] += item
+			}
+			continue
+		}
+		listKey = ""
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			// Remove surrounding quotes if present
+			value = strings.Trim(value, "\"")
+			result[key] = value
+			if value == "" {
+				listKey = key
+			}
+		}
+	}
+	return result
+}
+
+// listItem returns the value of a "- item" line. Items written as "- name: value" (e.g. structured
+// labels) yield their name.
+func listItem(line string) (string, bool) {
+	if line != "-" && !strings.HasPrefix(line, "- ") {
+		return "", false
+	}
+	item := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+	if name, ok := strings.CutPrefix(item, "name:"); ok {
+		item = strings.TrimSpace(name)
+	}
+	return strings.Trim(item, "\"'"), true
+}
+
+// isNestedLine reports whether a front matter line is part of a nested (indented or list) value.
+func isNestedLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ") || line == "-"
+}
+
+// RawFrontMatter returns the front matter block of a markdown file verbatim, including both
+// "---" fences, or an empty string if the file has no front matter. Line endings are normalized
+// to \n.
+func RawFrontMatter(path string) (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	lines := strings.Split(string(data), "\n")
-	inBlock := false
-	var body []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "---" {
-			if !inBlock {
-				inBlock = true
-				continue
-			} else {
-				inBlock = false
+	lines := strings.Split(NormalizeNewlines(TrimBOM(string(data))), "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			if start < 0 {
+				start = i
 				continue
 			}
+			return strings.Join(lines[start:i+1], "\n"), nil
 		}
-		if inBlock && line != "" {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				// Remove surrounding quotes if present
-				value = strings.Trim(value, "\"")
-				result[key] = value
-			}
-		} else if !inBlock {
-			body = append(body, line)
+		if start < 0 && strings.TrimSpace(line) != "" {
+			// Content before any fence means there is no front matter block
+			return "", nil
 		}
 	}
-	result["body"] = strings.Join(body, "\n")
-	return result, nil
+	return "", nil
 }
@@ -0,0 +1,172 @@
+package mdparser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			name:    "colon in unquoted title falls back to line reading",
+			content: "---\ntitle: Fix: login bug\ntype: Bug\n---\nbody\n",
+			want:    map[string]string{"title": "Fix: login bug", "type": "Bug", "body": "body"},
+		},
+		{
+			name:    "quoted values",
+			content: "---\ntitle: \"Fix: login bug\"\nparent: 'Auth epic'\nid: \"42\"\n---\nbody\n",
+			want:    map[string]string{"title": "Fix: login bug", "parent": "Auth epic", "id": "42", "body": "body"},
+		},
+		{
+			name:    "block list",
+			content: "---\ntitle: T\nlabels:\n  - bug\n  - ui\n---\n",
+			want:    map[string]string{"title": "T", "labels": "bug, ui", "body": ""},
+		},
+		{
+			name:    "flow list",
+			content: "---\ntitle: T\nlabels: [bug, \"good first issue\"]\n---\n",
+			want:    map[string]string{"title": "T", "labels": "bug, good first issue", "body": ""},
+		},
+		{
+			name:    "empty flow list",
+			content: "---\ntitle: T\nlabels: []\n---\n",
+			want:    map[string]string{"title": "T", "labels": "", "body": ""},
+		},
+		{
+			name:    "empty value",
+			content: "---\ntitle: T\nlabels:\n---\n",
+			want:    map[string]string{"title": "T", "labels": "", "body": ""},
+		},
+		{
+			name:    "structured labels yield their names",
+			content: "---\nlabels:\n  - name: bug\n    color: d73a4a\n  - ui\n---\n",
+			want:    map[string]string{"labels": "bug, ui", "body": ""},
+		},
+		{
+			name:    "block list in front matter that isn't valid YAML",
+			content: "---\ntitle: Fix: login bug\nlabels:\n  - bug\n  - name: ui\n---\n",
+			want:    map[string]string{"title": "Fix: login bug", "labels": "bug, ui", "body": ""},
+		},
+		{
+			name:    "body kept verbatim including indented code",
+			content: "---\ntitle: T\n---\nSteps:\n\n    go test ./...\n\tindented\n\n---\nafter a rule\n",
+			want:    map[string]string{"title": "T", "body": "Steps:\n\n    go test ./...\n\tindented\n\n---\nafter a rule"},
+		},
+		{
+			name:    "CRLF line endings",
+			content: "---\r\ntitle: T\r\nlabels:\r\n  - bug\r\n---\r\nline one\r\nline two\r\n",
+			want:    map[string]string{"title": "T", "labels": "bug", "body": "line one\nline two"},
+		},
+		{
+			name:    "CR line endings",
+			content: "---\rtitle: T\r---\rbody\r",
+			want:    map[string]string{"title": "T", "body": "body"},
+		},
+		{
+			name:    "leading BOM",
+			content: "\ufeff---\ntitle: T\n---\nbody\n",
+			want:    map[string]string{"title": "T", "body": "body"},
+		},
+		{
+			name:    "BOM with CRLF",
+			content: "\ufeff---\r\ntitle: T\r\n---\r\nbody\r\n",
+			want:    map[string]string{"title": "T", "body": "body"},
+		},
+		{
+			name:    "no front matter",
+			content: "just a body\n",
+			want:    map[string]string{"body": "just a body"},
+		},
+	}
+
+	dir := t.TempDir()
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, filepath.Join("case", string(rune('a'+i))+".md"), tt.content)
+			got, err := ParseFrontMatter(path)
+			if err != nil {
+				t.Fatalf("ParseFrontMatter: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFrontMatter = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFrontMatterNodes(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeFile(t, dir, "yaml.md", "---\nlabels: [bug]\nproject_fields:\n  Status: Todo\n---\n")
+	_, nodes, err := ReadFrontMatter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := SequenceValues(nodes["labels"]); !reflect.DeepEqual(got, []string{"bug"}) {
+		t.Errorf("labels = %v, want [bug]", got)
+	}
+	if nodes["project_fields"] == nil || len(nodes["project_fields"].Content) != 2 {
+		t.Errorf("project_fields node = %#v, want a one-entry mapping", nodes["project_fields"])
+	}
+
+	path = writeFile(t, dir, "flat.md", "---\ntitle: Fix: login bug\n---\n")
+	if _, nodes, err = ReadFrontMatter(path); err != nil {
+		t.Fatal(err)
+	}
+	if nodes != nil {
+		t.Errorf("nodes = %v, want nil for front matter that isn't valid YAML", nodes)
+	}
+}
+
+func TestListMarkdownFilesRecursive(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "issues")
+	writeFile(t, root, "top.md", "")
+	writeFile(t, root, "notes.txt", "")
+	writeFile(t, root, "bugs/login.md", "")
+	writeFile(t, root, "bugs/ui/button.md", "")
+	outside := writeFile(t, dir, "elsewhere/linked.md", "")
+	writeFile(t, dir, "elsewhere/hidden.md", "")
+
+	if err := os.Symlink(outside, filepath.Join(root, "bugs", "link.md")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "elsewhere"), filepath.Join(root, "linkdir")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "missing.md"), filepath.Join(root, "dangling.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ListMarkdownFilesRecursive(root)
+	if err != nil {
+		t.Fatalf("ListMarkdownFilesRecursive: %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "bugs", "link.md"),
+		filepath.Join(root, "bugs", "login.md"),
+		filepath.Join(root, "bugs", "ui", "button.md"),
+		filepath.Join(root, "top.md"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListMarkdownFilesRecursive = %v, want %v", got, want)
+	}
+}